@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"credCode/repository"
+)
+
+// maxRestoreBodyBytes caps the size of an uploaded (compressed) snapshot
+const maxRestoreBodyBytes = 512 << 20
+
+// AdminHandler handles administrative graph API requests
+// Every request must carry the admin token as "Authorization: Bearer <token>"
+type AdminHandler struct {
+	snapshotter repository.GraphSnapshotter
+	token       string
+}
+
+// NewAdminHandler creates a new admin handler
+// An empty token rejects every request
+func NewAdminHandler(snapshotter repository.GraphSnapshotter, token string) *AdminHandler {
+	return &AdminHandler{
+		snapshotter: snapshotter,
+		token:       token,
+	}
+}
+
+// authorized reports whether the request carries the admin token
+func (h *AdminHandler) authorized(r *http.Request) bool {
+	return hasAdminToken(r, h.token)
+}
+
+// hasAdminToken reports whether the request carries adminToken as "Authorization: Bearer <token>"
+// An empty adminToken rejects every request
+func hasAdminToken(r *http.Request, adminToken string) bool {
+	if adminToken == "" {
+		return false
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
+
+// Snapshot handles GET /api/v1/admin/snapshot
+func (h *AdminHandler) Snapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	if !h.authorized(r) {
+		WriteUnauthorized(w)
+		return
+	}
+
+	// Buffer the snapshot so that a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := h.snapshotter.Snapshot(r.Context(), &buf); err != nil {
+		WriteInternalServerError(w, "Error creating snapshot: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="graph-snapshot.json.gz"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// Restore handles POST /api/v1/admin/restore (body: snapshot file)
+func (h *AdminHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	if !h.authorized(r) {
+		WriteUnauthorized(w)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxRestoreBodyBytes)
+	if err := h.snapshotter.Restore(r.Context(), body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			WriteError(w, http.StatusRequestEntityTooLarge, "Snapshot too large")
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidSnapshot) {
+			WriteBadRequest(w, err.Error())
+			return
+		}
+		WriteInternalServerError(w, "Error restoring snapshot: "+err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{
+		"status": "restored",
+	})
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"credCode/repository"
+)
+
+const testAdminToken = "test-admin-token"
+
+// adminRequest creates a request carrying the test admin token
+func adminRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	return req
+}
+
+func TestAdminHandler_Snapshot(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	graphRepo.AddNodeWithName(context.Background(), "7379037972", "John")
+	handler := NewAdminHandler(graphRepo, testAdminToken)
+
+	req := adminRequest(http.MethodGet, "/api/v1/admin/snapshot", nil)
+	w := httptest.NewRecorder()
+
+	handler.Snapshot(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/gzip" {
+		t.Errorf("Expected Content-Type 'application/gzip', got '%s'", ct)
+	}
+
+	// The downloaded snapshot must restore into a fresh repository
+	target := repository.NewInMemoryGraphRepository()
+	restoreReq := adminRequest(http.MethodPost, "/api/v1/admin/restore", bytes.NewReader(w.Body.Bytes()))
+	restoreW := httptest.NewRecorder()
+
+	NewAdminHandler(target, testAdminToken).Restore(restoreW, restoreReq)
+
+	if restoreW.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", restoreW.Code)
+	}
+
+	if !target.NodeExists(context.Background(), "7379037972") {
+		t.Error("Expected node to be restored")
+	}
+}
+
+func TestAdminHandler_Restore_InvalidBody(t *testing.T) {
+	handler := NewAdminHandler(repository.NewInMemoryGraphRepository(), testAdminToken)
+
+	req := adminRequest(http.MethodPost, "/api/v1/admin/restore", bytes.NewBufferString("invalid"))
+	w := httptest.NewRecorder()
+
+	handler.Restore(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_WrongMethod(t *testing.T) {
+	handler := NewAdminHandler(repository.NewInMemoryGraphRepository(), testAdminToken)
+
+	w := httptest.NewRecorder()
+	handler.Snapshot(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/snapshot", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.Restore(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/restore", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_Unauthorized(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	graphRepo.AddNode(context.Background(), "7379037972")
+
+	tests := []struct {
+		name    string
+		handler *AdminHandler
+		header  string
+	}{
+		{"missing token", NewAdminHandler(graphRepo, testAdminToken), ""},
+		{"wrong token", NewAdminHandler(graphRepo, testAdminToken), "Bearer wrong"},
+		{"no token configured", NewAdminHandler(graphRepo, ""), "Bearer "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/restore", bytes.NewBufferString("invalid"))
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			tt.handler.Restore(w, req)
+
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("Expected status 401, got %d", w.Code)
+			}
+		})
+	}
+
+	if !graphRepo.NodeExists(context.Background(), "7379037972") {
+		t.Error("Expected unauthorized restores to leave the graph untouched")
+	}
+}
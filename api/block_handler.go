@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// BlockHandler handles block-list API requests
+type BlockHandler struct {
+	graphRepo repository.GraphRepository
+}
+
+// NewBlockHandler creates a new block handler
+func NewBlockHandler(graphRepo repository.GraphRepository) *BlockHandler {
+	return &BlockHandler{
+		graphRepo: graphRepo,
+	}
+}
+
+// HandleBlocks handles /api/v1/blocks
+// POST blocks a number, DELETE unblocks it, GET lists a user's blocks
+func (h *BlockHandler) HandleBlocks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.Block(w, r)
+	case http.MethodDelete:
+		h.Unblock(w, r)
+	case http.MethodGet:
+		h.ListBlocks(w, r)
+	default:
+		WriteMethodNotAllowed(w)
+	}
+}
+
+// Block handles POST /api/v1/blocks
+// Blocking an already blocked number returns 409 Conflict
+func (h *BlockHandler) Block(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	req, ok := decodeBlockRequest(w, r)
+	if !ok {
+		return
+	}
+
+	edge, err := h.graphRepo.AddEdgeWithMetadata(r.Context(), req.UserPhoneNumber, req.PhoneNumber, &models.BlockMetadata{})
+	if err != nil {
+		if errors.Is(err, repository.ErrEdgeExists) {
+			WriteConflict(w, "Number already blocked by this user")
+			return
+		}
+		WriteInternalServerError(w, "Error blocking number: "+err.Error())
+		return
+	}
+
+	var edgeJSON models.EdgeJSON
+	edgeJSON.FromEdge(edge)
+	WriteJSON(w, http.StatusCreated, edgeJSON)
+}
+
+// Unblock handles DELETE /api/v1/blocks
+func (h *BlockHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	req, ok := decodeBlockRequest(w, r)
+	if !ok {
+		return
+	}
+
+	edge, err := h.graphRepo.FindEdge(r.Context(), req.UserPhoneNumber, req.PhoneNumber, models.EdgeTypeBlock)
+	if err == nil {
+		err = h.graphRepo.DeleteEdge(r.Context(), edge.ID)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrEdgeNotFound) {
+			WriteNotFound(w, "Number is not blocked by this user")
+			return
+		}
+		WriteInternalServerError(w, "Error unblocking number: "+err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{
+		"status": "unblocked",
+	})
+}
+
+// ListBlocks handles GET /api/v1/blocks?user_phone_number=...
+func (h *BlockHandler) ListBlocks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	userPhoneNumber := r.URL.Query().Get("user_phone_number")
+	if userPhoneNumber == "" {
+		WriteBadRequest(w, "user_phone_number query parameter is required")
+		return
+	}
+
+	edges := h.graphRepo.GetOutgoingEdges(r.Context(), userPhoneNumber, models.EdgeTypeBlock)
+
+	blocked := make([]models.BlockedNumber, 0, len(edges))
+	for _, edge := range edges {
+		blocked = append(blocked, models.BlockedNumber{
+			PhoneNumber: edge.To,
+			BlockedAt:   edge.CreatedAt,
+		})
+	}
+
+	WriteSuccess(w, map[string]interface{}{
+		"user_phone_number": userPhoneNumber,
+		"blocked":           blocked,
+		"count":             len(blocked),
+	})
+}
+
+// decodeBlockRequest parses and validates a block request body
+// On failure it writes the error response and returns false
+func decodeBlockRequest(w http.ResponseWriter, r *http.Request) (*models.BlockRequest, bool) {
+	var req models.BlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body: "+err.Error())
+		return nil, false
+	}
+
+	if req.UserPhoneNumber == "" || req.PhoneNumber == "" {
+		WriteBadRequest(w, "user_phone_number and phone_number are required")
+		return nil, false
+	}
+
+	if req.UserPhoneNumber == req.PhoneNumber {
+		WriteBadRequest(w, "Users cannot block their own number")
+		return nil, false
+	}
+
+	return &req, true
+}
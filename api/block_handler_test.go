@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"credCode/repository"
+)
+
+// doBlock sends a request to the block handler and returns the recorder
+func doBlock(handler *BlockHandler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	handler.HandleBlocks(w, req)
+	return w
+}
+
+func TestBlockHandler_BlockListUnblock(t *testing.T) {
+	handler := NewBlockHandler(repository.NewInMemoryGraphRepository())
+
+	body := `{"user_phone_number": "7379037972", "phone_number": "14022000001"}`
+
+	if w := doBlock(handler, http.MethodPost, "/api/v1/blocks", body); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+
+	if w := doBlock(handler, http.MethodPost, "/api/v1/blocks", body); w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for duplicate block, got %d", w.Code)
+	}
+
+	w := doBlock(handler, http.MethodGet, "/api/v1/blocks?user_phone_number=7379037972", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var list struct {
+		Blocked []struct {
+			PhoneNumber string `json:"phone_number"`
+		} `json:"blocked"`
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if list.Count != 1 || list.Blocked[0].PhoneNumber != "14022000001" {
+		t.Errorf("Expected block list [14022000001], got %+v", list.Blocked)
+	}
+
+	if w := doBlock(handler, http.MethodDelete, "/api/v1/blocks", body); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for unblock, got %d", w.Code)
+	}
+
+	if w := doBlock(handler, http.MethodDelete, "/api/v1/blocks", body); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unblocking a number not blocked, got %d", w.Code)
+	}
+}
+
+func TestBlockHandler_Invalid(t *testing.T) {
+	handler := NewBlockHandler(repository.NewInMemoryGraphRepository())
+
+	bodies := []string{
+		`invalid`,
+		`{"user_phone_number": "7379037972"}`,
+		`{"user_phone_number": "7379037972", "phone_number": "7379037972"}`,
+	}
+
+	for _, body := range bodies {
+		if w := doBlock(handler, http.MethodPost, "/api/v1/blocks", body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+
+	if w := doBlock(handler, http.MethodGet, "/api/v1/blocks", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without user_phone_number, got %d", w.Code)
+	}
+
+	if w := doBlock(handler, http.MethodPut, "/api/v1/blocks", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
@@ -26,6 +26,16 @@ func WriteBadRequest(w http.ResponseWriter, message string) {
 	WriteError(w, http.StatusBadRequest, message)
 }
 
+// WriteNotFound writes a 404 Not Found error
+func WriteNotFound(w http.ResponseWriter, message string) {
+	WriteError(w, http.StatusNotFound, message)
+}
+
+// WriteConflict writes a 409 Conflict error
+func WriteConflict(w http.ResponseWriter, message string) {
+	WriteError(w, http.StatusConflict, message)
+}
+
 // WriteInternalServerError writes a 500 Internal Server Error
 func WriteInternalServerError(w http.ResponseWriter, message string) {
 	WriteError(w, http.StatusInternalServerError, message)
@@ -36,3 +46,8 @@ func WriteMethodNotAllowed(w http.ResponseWriter) {
 	WriteError(w, http.StatusMethodNotAllowed, "Method not allowed")
 }
 
+// WriteUnauthorized writes a 401 Unauthorized error
+func WriteUnauthorized(w http.ResponseWriter) {
+	WriteError(w, http.StatusUnauthorized, "Missing or invalid admin token")
+}
+
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// MessageHandler handles message (SMS) ingestion API requests
+type MessageHandler struct {
+	edges repository.EdgeRepository
+}
+
+// NewMessageHandler creates a new message handler
+func NewMessageHandler(edges repository.EdgeRepository) *MessageHandler {
+	return &MessageHandler{
+		edges: edges,
+	}
+}
+
+// RecordMessage handles POST /api/v1/messages
+func (h *MessageHandler) RecordMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	var req models.MessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if req.From == "" || req.To == "" {
+		WriteBadRequest(w, "from and to are required")
+		return
+	}
+
+	metadata := &models.MessageMetadata{
+		Timestamp:      req.Timestamp,
+		Length:         req.Length,
+		LinkCount:      req.LinkCount,
+		SenderIDType:   req.SenderIDType,
+		DeliveryStatus: req.DeliveryStatus,
+	}
+
+	if err := metadata.Validate(); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	edge, err := h.edges.AddEdgeWithMetadata(r.Context(), req.From, req.To, metadata)
+	if err != nil {
+		WriteInternalServerError(w, "Error recording message: "+err.Error())
+		return
+	}
+
+	var edgeJSON models.EdgeJSON
+	edgeJSON.FromEdge(edge)
+	WriteJSON(w, http.StatusCreated, edgeJSON)
+}
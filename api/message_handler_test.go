@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+func TestMessageHandler_RecordMessage(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	handler := NewMessageHandler(graphRepo)
+
+	body := `{"from": "VM-OFFERS", "to": "9876543210", "length": 158, "link_count": 2, "sender_id_type": "alphanumeric"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/messages", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	handler.RecordMessage(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var edge models.EdgeJSON
+	if err := json.NewDecoder(w.Body).Decode(&edge); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if edge.Type != models.EdgeTypeMessage || edge.ID == "" {
+		t.Errorf("Expected a message edge with an ID, got %+v", edge)
+	}
+
+	_, count := graphRepo.GetMessagesWithFilters(context.Background(), "VM-OFFERS", repository.MessageFilters{}, repository.DirectionOutgoing)
+	if count != 1 {
+		t.Errorf("Expected 1 stored message, got %d", count)
+	}
+}
+
+func TestMessageHandler_RecordMessage_Invalid(t *testing.T) {
+	handler := NewMessageHandler(repository.NewInMemoryGraphRepository())
+
+	bodies := []string{
+		`invalid`,
+		`{"to": "9876543210"}`,
+		`{"from": "7379037972", "to": "9876543210", "sender_id_type": "email"}`,
+		`{"from": "7379037972", "to": "9876543210", "length": -1}`,
+	}
+
+	for _, body := range bodies {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/messages", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+
+		handler.RecordMessage(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestMessageHandler_RecordMessage_MethodNotAllowed(t *testing.T) {
+	handler := NewMessageHandler(repository.NewInMemoryGraphRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/messages", nil)
+	w := httptest.NewRecorder()
+
+	handler.RecordMessage(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
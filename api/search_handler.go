@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"credCode/repository"
+	"credCode/service"
+)
+
+// Name search paging defaults
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+)
+
+// SearchHandler handles search API requests
+type SearchHandler struct {
+	callerID  *service.CallerIDService
+	nameIndex *repository.NameIndex
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(callerID *service.CallerIDService, nameIndex *repository.NameIndex) *SearchHandler {
+	return &SearchHandler{
+		callerID:  callerID,
+		nameIndex: nameIndex,
+	}
+}
+
+// Search handles GET /api/v1/search?phone=... and GET /api/v1/search?name=...
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case query.Get("phone") != "":
+		h.searchByPhone(w, r, query.Get("phone"))
+	case query.Get("name") != "":
+		h.searchByName(w, r, query.Get("name"))
+	default:
+		WriteBadRequest(w, "phone or name query parameter is required")
+	}
+}
+
+// searchByPhone returns the caller ID (consensus display name) of a number
+func (h *SearchHandler) searchByPhone(w http.ResponseWriter, r *http.Request, phoneNumber string) {
+	result, err := h.callerID.Lookup(r.Context(), phoneNumber)
+	if err != nil {
+		if errors.Is(err, repository.ErrNodeNotFound) {
+			WriteNotFound(w, "Phone number not found")
+			return
+		}
+		WriteInternalServerError(w, "Error looking up caller ID: "+err.Error())
+		return
+	}
+
+	WriteSuccess(w, result)
+}
+
+// searchByName returns the numbers whose names match, ranked and paginated
+// Query parameters: page (from 1, default 1) and page_size (default 20, at most 100)
+func (h *SearchHandler) searchByName(w http.ResponseWriter, r *http.Request, name string) {
+	page, err := positiveQueryInt(r, "page", 1)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	pageSize, err := positiveQueryInt(r, "page_size", defaultSearchPageSize)
+	if err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+
+	// The offset (page-1)*pageSize must not overflow
+	if page-1 > math.MaxInt/pageSize {
+		WriteBadRequest(w, "page is too large")
+		return
+	}
+
+	results, total := h.nameIndex.Search(name, (page-1)*pageSize, pageSize)
+
+	WriteSuccess(w, map[string]interface{}{
+		"name":      name,
+		"results":   results,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// positiveQueryInt parses an optional positive integer query parameter
+func positiveQueryInt(r *http.Request, key string, defaultValue int) (int, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 1 {
+		return 0, errors.New(key + " must be a positive integer")
+	}
+	return value, nil
+}
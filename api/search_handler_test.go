@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+func TestSearchHandler_SearchByPhone(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	ctx := context.Background()
+	graphRepo.AddEdgeWithMetadata(ctx, "9876543210", "7379037972", &models.ContactMetadata{Name: "John Smith", AddedAt: time.Now()})
+	graphRepo.AddEdgeWithMetadata(ctx, "5555555555", "7379037972", &models.ContactMetadata{Name: "Mr. John Smith", AddedAt: time.Now()})
+	handler := NewSearchHandler(service.NewCallerIDService(graphRepo, 0.8), repository.NewNameIndex())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?phone=7379037972", nil)
+	w := httptest.NewRecorder()
+	handler.Search(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var result models.CallerID
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Name != "John Smith" || result.SavedBy != 2 {
+		t.Errorf("Expected 'John Smith' saved by 2, got %+v", result)
+	}
+}
+
+func TestSearchHandler_Invalid(t *testing.T) {
+	handler := NewSearchHandler(service.NewCallerIDService(repository.NewInMemoryGraphRepository(), 0.8), repository.NewNameIndex())
+
+	tests := []struct {
+		method, target string
+		expected       int
+	}{
+		{http.MethodGet, "/api/v1/search", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/search?phone=0000000000", http.StatusNotFound},
+		{http.MethodGet, "/api/v1/search?name=john&page=0", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/search?name=john&page_size=x", http.StatusBadRequest},
+		{http.MethodGet, "/api/v1/search?name=john&page=922337203685477580", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/search?phone=7379037972", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.Search(w, httptest.NewRequest(tt.method, tt.target, nil))
+		if w.Code != tt.expected {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.target, tt.expected, w.Code)
+		}
+	}
+}
+
+func TestSearchHandler_SearchByName(t *testing.T) {
+	nameIndex := repository.NewNameIndex()
+	for i, phoneNumber := range []string{"1000000001", "1000000002", "1000000003"} {
+		nameIndex.Add(phoneNumber, "John Smith", models.NameSourceUser)
+		for j := 0; j < i; j++ {
+			nameIndex.Add(phoneNumber, "John Smith", models.NameSourceContact)
+		}
+	}
+	handler := NewSearchHandler(service.NewCallerIDService(repository.NewInMemoryGraphRepository(), 0.8), nameIndex)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?name=jon+smith&page=2&page_size=2", nil)
+	w := httptest.NewRecorder()
+	handler.Search(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var response struct {
+		Results []models.NameSearchResult `json:"results"`
+		Total   int                       `json:"total"`
+		Page    int                       `json:"page"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// The least saved of three matches is alone on the second page
+	if response.Total != 3 || response.Page != 2 || len(response.Results) != 1 || response.Results[0].PhoneNumber != "1000000001" {
+		t.Errorf("Unexpected response %+v", response)
+	}
+}
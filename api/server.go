@@ -5,20 +5,38 @@ import (
 	"log"
 	"net/http"
 
+	"credCode/repository"
 	"credCode/service"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	handler *SpamDetectionHandler
-	port    string
+	handler        *SpamDetectionHandler
+	adminHandler   *AdminHandler
+	labelHandler   *SpamLabelHandler
+	trustHandler   *TrustRankHandler
+	messageHandler *MessageHandler
+	reportHandler  *SpamReportHandler
+	blockHandler   *BlockHandler
+	searchHandler  *SearchHandler
+	adminEnabled   bool
+	port           string
 }
 
 // NewServer creates a new HTTP server
-func NewServer(spamService *service.SpamDetectionService, port string) *Server {
+// The snapshot, restore, spam label and trust rank endpoints are only served when an admin token is configured
+func NewServer(spamService *service.SpamDetectionService, callerIDService *service.CallerIDService, nameIndex *repository.NameIndex, graphRepo repository.GraphRepository, trustRankJob *service.TrustRankJob, adminToken string, port string) *Server {
 	return &Server{
-		handler: NewSpamDetectionHandler(spamService),
-		port:    port,
+		handler:        NewSpamDetectionHandler(spamService),
+		adminHandler:   NewAdminHandler(graphRepo, adminToken),
+		labelHandler:   NewSpamLabelHandler(graphRepo, adminToken),
+		trustHandler:   NewTrustRankHandler(trustRankJob, adminToken),
+		messageHandler: NewMessageHandler(graphRepo),
+		reportHandler:  NewSpamReportHandler(graphRepo),
+		blockHandler:   NewBlockHandler(graphRepo),
+		searchHandler:  NewSearchHandler(callerIDService, nameIndex),
+		adminEnabled:   adminToken != "",
+		port:           port,
 	}
 }
 
@@ -28,6 +46,16 @@ func (s *Server) Start() error {
 	http.HandleFunc("/api/v1/spam/detect", s.handler.DetectSpam)
 	http.HandleFunc("/api/v1/spam/score", s.handler.GetSpamScore)
 	http.HandleFunc("/api/v1/spam/rules", s.handler.GetRules)
+	http.HandleFunc("/api/v1/messages", s.messageHandler.RecordMessage)
+	http.HandleFunc("/api/v1/report-spam", s.reportHandler.HandleReport)
+	http.HandleFunc("/api/v1/blocks", s.blockHandler.HandleBlocks)
+	http.HandleFunc("/api/v1/search", s.searchHandler.Search)
+	if s.adminEnabled {
+		http.HandleFunc("/api/v1/admin/snapshot", s.adminHandler.Snapshot)
+		http.HandleFunc("/api/v1/admin/restore", s.adminHandler.Restore)
+		http.HandleFunc("/api/v1/admin/spam-labels", s.labelHandler.HandleSpamLabels)
+		http.HandleFunc("/api/v1/admin/trust-rank", s.trustHandler.Recompute)
+	}
 	http.HandleFunc("/health", s.healthCheck)
 
 	addr := fmt.Sprintf(":%s", s.port)
@@ -36,6 +64,22 @@ func (s *Server) Start() error {
 	log.Printf("  POST /api/v1/spam/detect - Detect spam (JSON: phone_number, user_phone_number)")
 	log.Printf("  GET  /api/v1/spam/score  - Get spam score (query: phone_number, user_phone_number)")
 	log.Printf("  GET  /api/v1/spam/rules  - Get registered rules")
+	log.Printf("  POST /api/v1/messages    - Record a message (JSON: from, to, length, link_count, sender_id_type, delivery_status)")
+	log.Printf("  POST /api/v1/report-spam - Report a spam number (JSON: phone_number, reporter_phone_number, category)")
+	log.Printf("  DELETE /api/v1/report-spam - Undo a spam report (JSON: phone_number, reporter_phone_number)")
+	log.Printf("  POST /api/v1/blocks      - Block a number (JSON: user_phone_number, phone_number)")
+	log.Printf("  DELETE /api/v1/blocks    - Unblock a number (JSON: user_phone_number, phone_number)")
+	log.Printf("  GET  /api/v1/blocks      - List blocked numbers (query: user_phone_number)")
+	log.Printf("  GET  /api/v1/search      - Caller ID of a number (query: phone)")
+	log.Printf("  GET  /api/v1/search      - Search numbers by name (query: name, page, page_size)")
+	if s.adminEnabled {
+		log.Printf("  GET  /api/v1/admin/snapshot - Download graph snapshot (header: Authorization: Bearer <admin token>)")
+		log.Printf("  POST /api/v1/admin/restore  - Restore graph from snapshot (header: Authorization: Bearer <admin token>)")
+		log.Printf("  POST /api/v1/admin/spam-labels - Label a confirmed spam number (JSON: phone_number, source; header: Authorization: Bearer <admin token>)")
+		log.Printf("  DELETE /api/v1/admin/spam-labels - Remove a spam label (JSON: phone_number; header: Authorization: Bearer <admin token>)")
+		log.Printf("  GET  /api/v1/admin/spam-labels - List labeled spam numbers (header: Authorization: Bearer <admin token>)")
+		log.Printf("  POST /api/v1/admin/trust-rank - Recompute trust rank (query: full; header: Authorization: Bearer <admin token>)")
+	}
 	log.Printf("  GET  /health             - Health check")
 
 	return http.ListenAndServe(addr, nil)
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// SpamLabelHandler handles the operator API for the confirmed spam number collection
+// Every request must carry the admin token as "Authorization: Bearer <token>"
+type SpamLabelHandler struct {
+	labels repository.SpamLabelRepository
+	token  string
+}
+
+// NewSpamLabelHandler creates a new spam label handler
+// An empty token rejects every request
+func NewSpamLabelHandler(labels repository.SpamLabelRepository, token string) *SpamLabelHandler {
+	return &SpamLabelHandler{
+		labels: labels,
+		token:  token,
+	}
+}
+
+// HandleSpamLabels handles /api/v1/admin/spam-labels
+// POST labels a number as confirmed spam, DELETE removes the label, GET lists labeled numbers
+func (h *SpamLabelHandler) HandleSpamLabels(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.Label(w, r)
+	case http.MethodDelete:
+		h.Unlabel(w, r)
+	case http.MethodGet:
+		h.ListLabels(w, r)
+	default:
+		WriteMethodNotAllowed(w)
+	}
+}
+
+// Label handles POST /api/v1/admin/spam-labels
+func (h *SpamLabelHandler) Label(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	if !hasAdminToken(r, h.token) {
+		WriteUnauthorized(w)
+		return
+	}
+
+	req, ok := decodeSpamLabelRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if req.Source == "" {
+		req.Source = models.SpamLabelSourceManual
+	}
+
+	if err := h.labels.LabelSpamNumber(r.Context(), req.PhoneNumber, req.Source); err != nil {
+		WriteInternalServerError(w, "Error labeling number: "+err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, models.SpamLabel{
+		PhoneNumber: req.PhoneNumber,
+		Source:      req.Source,
+	})
+}
+
+// Unlabel handles DELETE /api/v1/admin/spam-labels
+func (h *SpamLabelHandler) Unlabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	if !hasAdminToken(r, h.token) {
+		WriteUnauthorized(w)
+		return
+	}
+
+	req, ok := decodeSpamLabelRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.labels.UnlabelSpamNumber(r.Context(), req.PhoneNumber); err != nil {
+		if errors.Is(err, repository.ErrSpamLabelNotFound) {
+			WriteNotFound(w, "Number is not labeled as spam")
+			return
+		}
+		WriteInternalServerError(w, "Error removing label: "+err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{
+		"status": "removed",
+	})
+}
+
+// ListLabels handles GET /api/v1/admin/spam-labels
+func (h *SpamLabelHandler) ListLabels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	if !hasAdminToken(r, h.token) {
+		WriteUnauthorized(w)
+		return
+	}
+
+	labels := h.labels.GetSpamLabels(r.Context())
+
+	WriteSuccess(w, map[string]interface{}{
+		"labels": labels,
+		"count":  len(labels),
+	})
+}
+
+// decodeSpamLabelRequest parses and validates a spam label request body
+// On failure it writes the error response and returns false
+func decodeSpamLabelRequest(w http.ResponseWriter, r *http.Request) (*models.SpamLabelRequest, bool) {
+	var req models.SpamLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body: "+err.Error())
+		return nil, false
+	}
+
+	if req.PhoneNumber == "" {
+		WriteBadRequest(w, "phone_number is required")
+		return nil, false
+	}
+
+	return &req, true
+}
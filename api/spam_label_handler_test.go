@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"credCode/repository"
+)
+
+// doSpamLabel sends a request to the spam label handler and returns the recorder
+func doSpamLabel(handler *SpamLabelHandler, method, body string) *httptest.ResponseRecorder {
+	req := adminRequest(method, "/api/v1/admin/spam-labels", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	handler.HandleSpamLabels(w, req)
+	return w
+}
+
+func TestSpamLabelHandler_LabelListUnlabel(t *testing.T) {
+	handler := NewSpamLabelHandler(repository.NewInMemoryGraphRepository(), testAdminToken)
+
+	if w := doSpamLabel(handler, http.MethodPost, `{"phone_number": "14022000001", "source": "fcc"}`); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", w.Code)
+	}
+
+	w := doSpamLabel(handler, http.MethodGet, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var list struct {
+		Labels []struct {
+			PhoneNumber string `json:"phone_number"`
+			Source      string `json:"source"`
+		} `json:"labels"`
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if list.Count != 1 || list.Labels[0].PhoneNumber != "14022000001" || list.Labels[0].Source != "fcc" {
+		t.Errorf("Expected labels [14022000001 (fcc)], got %+v", list.Labels)
+	}
+
+	if w := doSpamLabel(handler, http.MethodDelete, `{"phone_number": "14022000001"}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for unlabel, got %d", w.Code)
+	}
+
+	if w := doSpamLabel(handler, http.MethodDelete, `{"phone_number": "14022000001"}`); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for a number not labeled, got %d", w.Code)
+	}
+}
+
+func TestSpamLabelHandler_Invalid(t *testing.T) {
+	handler := NewSpamLabelHandler(repository.NewInMemoryGraphRepository(), testAdminToken)
+
+	for _, body := range []string{`invalid`, `{"source": "fcc"}`} {
+		if w := doSpamLabel(handler, http.MethodPost, body); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+
+	if w := doSpamLabel(handler, http.MethodPut, ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
+
+func TestSpamLabelHandler_Unauthorized(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+
+	tests := []struct {
+		name    string
+		handler *SpamLabelHandler
+		header  string
+	}{
+		{"missing token", NewSpamLabelHandler(graphRepo, testAdminToken), ""},
+		{"wrong token", NewSpamLabelHandler(graphRepo, testAdminToken), "Bearer wrong"},
+		{"no token configured", NewSpamLabelHandler(graphRepo, ""), "Bearer "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range []string{http.MethodPost, http.MethodDelete, http.MethodGet} {
+				req := httptest.NewRequest(method, "/api/v1/admin/spam-labels", bytes.NewBufferString(`{"phone_number": "14022000001"}`))
+				if tt.header != "" {
+					req.Header.Set("Authorization", tt.header)
+				}
+				w := httptest.NewRecorder()
+
+				tt.handler.HandleSpamLabels(w, req)
+
+				if w.Code != http.StatusUnauthorized {
+					t.Errorf("Expected status 401 for %s, got %d", method, w.Code)
+				}
+			}
+		})
+	}
+
+	if graphRepo.IsLabeledSpam(context.Background(), "14022000001") {
+		t.Error("Expected unauthorized requests to leave the labels untouched")
+	}
+}
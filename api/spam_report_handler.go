@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// SpamReportHandler handles user spam report API requests
+type SpamReportHandler struct {
+	edges repository.EdgeRepository
+}
+
+// NewSpamReportHandler creates a new spam report handler
+func NewSpamReportHandler(edges repository.EdgeRepository) *SpamReportHandler {
+	return &SpamReportHandler{
+		edges: edges,
+	}
+}
+
+// HandleReport handles /api/v1/report-spam
+// POST files a report, DELETE undoes the reporter's report
+func (h *SpamReportHandler) HandleReport(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.ReportSpam(w, r)
+	case http.MethodDelete:
+		h.UndoReport(w, r)
+	default:
+		WriteMethodNotAllowed(w)
+	}
+}
+
+// ReportSpam handles POST /api/v1/report-spam
+// Each reporter can report a number once; a repeat report returns 409 Conflict
+func (h *SpamReportHandler) ReportSpam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	req, ok := decodeSpamReportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	metadata := &models.SpamReportMetadata{
+		Category: req.Category,
+	}
+
+	if err := metadata.Validate(); err != nil {
+		WriteBadRequest(w, err.Error())
+		return
+	}
+
+	edge, err := h.edges.AddEdgeWithMetadata(r.Context(), req.ReporterPhoneNumber, req.PhoneNumber, metadata)
+	if err != nil {
+		if errors.Is(err, repository.ErrEdgeExists) {
+			WriteConflict(w, "Number already reported by this user")
+			return
+		}
+		WriteInternalServerError(w, "Error recording spam report: "+err.Error())
+		return
+	}
+
+	var edgeJSON models.EdgeJSON
+	edgeJSON.FromEdge(edge)
+	WriteJSON(w, http.StatusCreated, edgeJSON)
+}
+
+// UndoReport handles DELETE /api/v1/report-spam
+func (h *SpamReportHandler) UndoReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	req, ok := decodeSpamReportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	edge, err := h.edges.FindEdge(r.Context(), req.ReporterPhoneNumber, req.PhoneNumber, models.EdgeTypeSpamReport)
+	if err == nil {
+		err = h.edges.DeleteEdge(r.Context(), edge.ID)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrEdgeNotFound) {
+			WriteNotFound(w, "No spam report from this user for the number")
+			return
+		}
+		WriteInternalServerError(w, "Error undoing spam report: "+err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]string{
+		"status": "removed",
+	})
+}
+
+// decodeSpamReportRequest parses and validates a spam report request body
+// On failure it writes the error response and returns false
+func decodeSpamReportRequest(w http.ResponseWriter, r *http.Request) (*models.SpamReportRequest, bool) {
+	var req models.SpamReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteBadRequest(w, "Invalid request body: "+err.Error())
+		return nil, false
+	}
+
+	if req.PhoneNumber == "" || req.ReporterPhoneNumber == "" {
+		WriteBadRequest(w, "phone_number and reporter_phone_number are required")
+		return nil, false
+	}
+
+	if req.PhoneNumber == req.ReporterPhoneNumber {
+		WriteBadRequest(w, "Users cannot report their own number")
+		return nil, false
+	}
+
+	return &req, true
+}
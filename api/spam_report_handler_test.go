@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// doSpamReport sends a request to the spam report handler and returns the status code
+func doSpamReport(handler *SpamReportHandler, method, body string) int {
+	req := httptest.NewRequest(method, "/api/v1/report-spam", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	handler.HandleReport(w, req)
+	return w.Code
+}
+
+func TestSpamReportHandler_ReportAndUndo(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	handler := NewSpamReportHandler(graphRepo)
+	ctx := context.Background()
+
+	body := `{"phone_number": "14022000001", "reporter_phone_number": "7379037972", "category": "robocall"}`
+
+	if code := doSpamReport(handler, http.MethodPost, body); code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", code)
+	}
+
+	if reports := graphRepo.GetIncomingEdges(ctx, "14022000001", models.EdgeTypeSpamReport); len(reports) != 1 {
+		t.Fatalf("Expected 1 spam report, got %d", len(reports))
+	}
+
+	// The same reporter cannot report the number twice
+	if code := doSpamReport(handler, http.MethodPost, body); code != http.StatusConflict {
+		t.Errorf("Expected status 409 for duplicate report, got %d", code)
+	}
+
+	// Another reporter can
+	other := `{"phone_number": "14022000001", "reporter_phone_number": "9876543210", "category": "fraud"}`
+	if code := doSpamReport(handler, http.MethodPost, other); code != http.StatusCreated {
+		t.Errorf("Expected status 201 for second reporter, got %d", code)
+	}
+
+	// Undo the first report
+	if code := doSpamReport(handler, http.MethodDelete, body); code != http.StatusOK {
+		t.Fatalf("Expected status 200 for undo, got %d", code)
+	}
+
+	if reports := graphRepo.GetIncomingEdges(ctx, "14022000001", models.EdgeTypeSpamReport); len(reports) != 1 {
+		t.Errorf("Expected 1 spam report after undo, got %d", len(reports))
+	}
+
+	if code := doSpamReport(handler, http.MethodDelete, body); code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for undoing a missing report, got %d", code)
+	}
+}
+
+func TestSpamReportHandler_Invalid(t *testing.T) {
+	handler := NewSpamReportHandler(repository.NewInMemoryGraphRepository())
+
+	bodies := []string{
+		`invalid`,
+		`{"phone_number": "14022000001"}`,
+		`{"phone_number": "14022000001", "reporter_phone_number": "7379037972", "category": "annoying"}`,
+		`{"phone_number": "7379037972", "reporter_phone_number": "7379037972", "category": "fraud"}`,
+	}
+
+	for _, body := range bodies {
+		if code := doSpamReport(handler, http.MethodPost, body); code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, code)
+		}
+	}
+
+	if code := doSpamReport(handler, http.MethodGet, ""); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", code)
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"credCode/service"
+)
+
+// TrustRankHandler handles the operator API for recomputing trust rank in the running server
+// Every request must carry the admin token as "Authorization: Bearer <token>"
+type TrustRankHandler struct {
+	job   *service.TrustRankJob
+	token string
+}
+
+// NewTrustRankHandler creates a new trust rank handler
+// An empty token rejects every request
+func NewTrustRankHandler(job *service.TrustRankJob, token string) *TrustRankHandler {
+	return &TrustRankHandler{
+		job:   job,
+		token: token,
+	}
+}
+
+// Recompute handles POST /api/v1/admin/trust-rank (query: full=true to recompute from scratch)
+// The recompute is incremental by default, warm-starting from the stored scores
+func (h *TrustRankHandler) Recompute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteMethodNotAllowed(w)
+		return
+	}
+
+	if !hasAdminToken(r, h.token) {
+		WriteUnauthorized(w)
+		return
+	}
+
+	run := h.job.RunIncremental
+	if r.URL.Query().Get("full") == "true" {
+		run = h.job.Run
+	}
+
+	result, err := run(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrTrustRankRunning) {
+			WriteConflict(w, err.Error())
+			return
+		}
+		WriteInternalServerError(w, "Error computing trust rank: "+err.Error())
+		return
+	}
+
+	WriteSuccess(w, map[string]int{
+		"nodes":      result.Nodes,
+		"seeds":      result.Seeds,
+		"iterations": result.Iterations,
+		"updated":    result.Updated,
+	})
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+func TestTrustRankHandler_Recompute(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	ctx := context.Background()
+	graphRepo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Priya", AddedAt: time.Now()})
+	graphRepo.AddEdgeWithMetadata(ctx, "9876543210", "7379037972", &models.ContactMetadata{Name: "John", AddedAt: time.Now()})
+	handler := NewTrustRankHandler(service.NewTrustRankJob(graphRepo, 0.85, 50, 1, nil), testAdminToken)
+
+	for _, target := range []string{"/api/v1/admin/trust-rank?full=true", "/api/v1/admin/trust-rank"} {
+		w := httptest.NewRecorder()
+		handler.Recompute(w, adminRequest(http.MethodPost, target, nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 for %s, got %d", target, w.Code)
+		}
+
+		var result map[string]int
+		if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if result["nodes"] != 2 {
+			t.Errorf("Expected 2 nodes scored for %s, got %v", target, result)
+		}
+	}
+
+	if _, ok := graphRepo.GetNodeProperty(ctx, "7379037972", repository.NodePropertyTrustRank); !ok {
+		t.Error("Expected trust rank to be stored")
+	}
+}
+
+func TestTrustRankHandler_Unauthorized(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	graphRepo.AddNode(context.Background(), "7379037972")
+	handler := NewTrustRankHandler(service.NewTrustRankJob(graphRepo, 0.85, 50, 1, nil), testAdminToken)
+
+	w := httptest.NewRecorder()
+	handler.Recompute(w, httptest.NewRequest(http.MethodPost, "/api/v1/admin/trust-rank", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.Recompute(w, adminRequest(http.MethodGet, "/api/v1/admin/trust-rank", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
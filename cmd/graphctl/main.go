@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"credCode/config"
+	"credCode/di"
+	"credCode/repository"
+	"credCode/service/scoring"
+)
+
+const usage = `Usage: graphctl <command> [flags]
+
+Commands:
+  snapshot -file <path>   Write a snapshot of the graph to a file
+  restore  -file <path>   Replace the graph with a snapshot file
+  trustrank [-incremental] Recompute trust rank scores for every node
+  fitbayes -labels <path> Fit the bayes scorer from labeled numbers and print its settings
+
+The graph backend is selected by GRAPH_BACKEND and GRAPH_DATA_PATH.
+With MUTATION_LOG_PATH set, graphctl cannot run while the server holds the mutation log.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	filePath := flags.String("file", "graph-snapshot.json.gz", "snapshot file path")
+	incremental := flags.Bool("incremental", false, "warm-start from stored trust rank scores")
+	labelsPath := flags.String("labels", "labeled-numbers.json", "labeled numbers: [{\"phone_number\": ..., \"is_spam\": ...}]")
+	smoothing := flags.Float64("smoothing", 1.0, "pseudo-count added to every count when fitting")
+	flags.Parse(os.Args[2:])
+
+	// Load configuration
+	cfg := config.Load()
+
+	switch command {
+	case "snapshot":
+		if err := snapshot(cfg, *filePath); err != nil {
+			log.Fatalf("Snapshot failed: %v", err)
+		}
+		log.Printf("✓ Graph snapshot written to %s", *filePath)
+	case "restore":
+		if err := restore(cfg, *filePath); err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+		log.Printf("✓ Graph restored from %s", *filePath)
+	case "trustrank":
+		if err := trustRank(cfg, *incremental); err != nil {
+			log.Fatalf("Trust rank failed: %v", err)
+		}
+	case "fitbayes":
+		if err := fitBayes(cfg, *labelsPath, *smoothing); err != nil {
+			log.Fatalf("Fitting failed: %v", err)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// snapshot writes the configured graph to filePath
+func snapshot(cfg *config.Config, filePath string) error {
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := container.GetGraphRepo().Snapshot(context.Background(), file); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// trustRank recomputes trust rank scores on the configured graph
+func trustRank(cfg *config.Config, incremental bool) error {
+	// Scores computed on an in-memory store would be lost on exit, unless they are logged
+	// A running server recomputes its own scores with POST /api/v1/admin/trust-rank
+	if (cfg.GraphBackend == "" || cfg.GraphBackend == repository.GraphBackendMemory) && cfg.MutationLogPath == "" {
+		return fmt.Errorf("trustrank requires a persistent graph backend or a mutation log (set GRAPH_BACKEND or MUTATION_LOG_PATH)")
+	}
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	job := container.GetTrustRankJob()
+	run := job.Run
+	if incremental {
+		run = job.RunIncremental
+	}
+
+	result, err := run(context.Background())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Trust rank computed for %d nodes from %d seeds (%d iterations, %d updated)",
+		result.Nodes, result.Seeds, result.Iterations, result.Updated)
+	return nil
+}
+
+// labeledNumber is a phone number whose verdict is known
+type labeledNumber struct {
+	PhoneNumber string `json:"phone_number"`
+	IsSpam      bool   `json:"is_spam"`
+}
+
+// fitBayes scores every labeled number with the registered rules, fits the bayes scorer
+// and prints the fitted prior and likelihood ratios as environment settings
+// Numbers should be labeled independently of the spam labels the known spammer rule reads,
+// or that rule's evidence is overstated
+func fitBayes(cfg *config.Config, labelsPath string, smoothing float64) error {
+	data, err := os.ReadFile(labelsPath)
+	if err != nil {
+		return err
+	}
+
+	var labeled []labeledNumber
+	if err := json.Unmarshal(data, &labeled); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", labelsPath, err)
+	}
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	examples := make([]scoring.LabeledScores, 0, len(labeled))
+	for _, number := range labeled {
+		result, err := container.GetSpamService().DetectSpam(number.PhoneNumber, "")
+		if err != nil {
+			return fmt.Errorf("failed to score %s: %w", number.PhoneNumber, err)
+		}
+		examples = append(examples, scoring.LabeledScores{
+			Scores: result.RuleScores,
+			IsSpam: number.IsSpam,
+		})
+	}
+
+	prior, ratios, err := scoring.FitBayes(examples, smoothing)
+	if err != nil {
+		return err
+	}
+
+	ruleNames := make([]string, 0, len(ratios))
+	for ruleName := range ratios {
+		ruleNames = append(ruleNames, ruleName)
+	}
+	sort.Strings(ruleNames)
+
+	entries := make([]string, 0, len(ruleNames))
+	for _, ruleName := range ruleNames {
+		entries = append(entries, fmt.Sprintf("%s=%.4g:%.4g", ruleName, ratios[ruleName].Fired, ratios[ruleName].Quiet))
+	}
+
+	log.Printf("✓ Fitted from %d labeled numbers", len(examples))
+	fmt.Printf("SCORING_STRATEGY=bayes\n")
+	fmt.Printf("SPAM_PRIOR_RATE=%.4g\n", prior)
+	fmt.Printf("RULE_LIKELIHOOD_RATIOS=%s\n", strings.Join(entries, ","))
+	return nil
+}
+
+// restore replaces the configured graph with the snapshot at filePath
+func restore(cfg *config.Config, filePath string) error {
+	// Restoring into an in-memory store would be lost on exit, unless it is checkpointed
+	if (cfg.GraphBackend == "" || cfg.GraphBackend == repository.GraphBackendMemory) && cfg.MutationLogPath == "" {
+		return fmt.Errorf("restore requires a persistent graph backend or a mutation log (set GRAPH_BACKEND or MUTATION_LOG_PATH)")
+	}
+
+	container, err := di.NewContainer(cfg)
+	if err != nil {
+		return err
+	}
+	defer container.Close()
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return container.GetGraphRepo().Restore(context.Background(), file)
+}
@@ -23,5 +23,11 @@ func main() {
 	server := container.GetServer()
 
 	log.Println("=== Server Ready ===")
-	log.Fatal(server.Start())
+	err = server.Start()
+
+	// Flush and close the graph store before exiting
+	if closeErr := container.Close(); closeErr != nil {
+		log.Printf("Failed to close container: %v", closeErr)
+	}
+	log.Fatal(err)
 }
@@ -6,11 +6,27 @@ type Config struct {
 	UserSeedDataPath string
 	CallDataPath     string
 
+	// Graph storage configuration
+	GraphBackend  string // "memory", "leveldb" or "bolt" (bolt crashes in -race builds)
+	GraphDataPath string // Directory for persistent graph backends
+
+	// Graph durability configuration
+	MutationLogPath   string // Write-ahead log of graph mutations (empty disables logging)
+	GraphSnapshotPath string // Snapshot the mutation log is replayed on top of
+
 	// Server configuration
 	ServerPort string
+	AdminToken string // Bearer token for the snapshot and restore endpoints (empty disables them)
 
 	// Spam detection configuration
-	SpamThreshold float64
+	SpamThreshold   float64
+	ScoringStrategy string             // "weighted", "bayes" or "average"
+	RuleWeights     map[string]float64 // Rule name -> weight for the weighted scorer (unlisted rules weigh 1.0)
+	SpamVetoes      map[string]float64 // Rule name -> score at or above which the number is spam, whatever the other rules say
+	SpamPriorRate   float64            // Share of numbers that are spam before any rule is consulted (bayes scorer)
+
+	// Rule name -> {fired, quiet} likelihood ratios for the bayes scorer; fit with graphctl fitbayes
+	RuleLikelihoodRatios map[string][2]float64
 
 	// Rule configurations
 	ContactCountThreshold        int
@@ -20,6 +36,50 @@ type Config struct {
 	CallPatternSuspiciousWeight  float64
 	SecondLevelThreshold         int
 	SecondLevelMaxScore          float64
+	SpamReportMaxScore           float64
+	SpamReportHalfLife           string // Duration string like "720h"
+	SpamReportMatureAge          string // Duration string like "2160h"
+	SpamReportTrustedContacts    int
+	SpamReportConsensusTrust     float64
+	BlockThreshold               int
+	BlockMaxScore                float64
+	CallVelocityPerMinute        int // Outbound calls to non-contacts allowed per minute (0 disables)
+	CallVelocityPerHour          int
+	CallVelocityPerDay           int
+	CallVelocityMaxScore         float64
+	FanOutTimeWindow             string // Duration string like "168h"
+	FanOutThreshold              int
+	FanOutMaxScore               float64
+	UnansweredTimeWindow         string // Duration string like "24h"
+	UnansweredRingDropDuration   int    // Max seconds of a ring-and-drop call
+	UnansweredMinCallCount       int
+	UnansweredRatioThreshold     float64
+	UnansweredMaxScore           float64
+	RiskyCallerPrefixes          []string // International and premium-rate prefixes
+	HomeCallerPrefixes           []string // Home country prefixes excluded from RiskyCallerPrefixes
+	TimeOfDayTimeWindow          string   // Duration string like "168h"
+	TimeOfDayMinCallCount        int
+	TimeOfDayMaxScore            float64
+	PrefixTimezones              map[string]string // IANA timezone by number prefix
+	DefaultTimezone              string            // IANA timezone for numbers matching no prefix
+	NumberSeriesPrefixLength     int
+	NumberSeriesMinHistory       int
+	NumberSeriesMinSeriesSize    int
+	NumberSeriesMaxScore         float64
+	TrustRankDamping             float64
+	TrustRankMaxIterations       int
+	TrustRankSeedMinSaves        int      // Users saved by at least this many others seed trust
+	TrustRankSeedNumbers         []string // Verified numbers that always seed trust
+	TrustRankThreshold           float64
+	TrustRankMaxScore            float64
+	KnownSpammerTimeWindow       string // Duration string like "720h"
+	KnownSpammerMinShared        int    // Shared neighbours required before an overlap counts
+	KnownSpammerMatchThreshold   float64
+	KnownSpammerMaxScore         float64
+	CallerIDNameSimilarity       float64            // Edit similarity at which two contact names are the same name
+	SpamNameLexicon              map[string]float64 // Spam-indicative contact name term -> weight
+	ContactLabelMinSavers        int
+	ContactLabelMaxScore         float64
 }
 
 // DefaultConfig returns a configuration with default values
@@ -27,14 +87,104 @@ func DefaultConfig() *Config {
 	return &Config{
 		UserSeedDataPath:             "contacts_generated.json",
 		CallDataPath:                 "call_data.json",
+		GraphBackend:                 "memory",
+		GraphDataPath:                "data/graph",
+		MutationLogPath:              "",
+		GraphSnapshotPath:            "data/graph-snapshot.json.gz",
 		ServerPort:                   "8080",
 		SpamThreshold:                0.5,
 		ContactCountThreshold:        3,
-		ContactCountMaxScore:         0.7,
+		ContactCountMaxScore:         0.45, // Below SpamThreshold: not being saved is not spam on its own
 		CallPatternDurationThreshold: 30,
 		CallPatternTimeWindow:        "60m",
 		CallPatternSuspiciousWeight:  0.6,
 		SecondLevelThreshold:         2,
 		SecondLevelMaxScore:          0.5,
+		SpamReportMaxScore:           0.9,
+		SpamReportHalfLife:           "720h",
+		SpamReportMatureAge:          "2160h",
+		SpamReportTrustedContacts:    3,
+		SpamReportConsensusTrust:     1.0,
+		BlockThreshold:               5,
+		BlockMaxScore:                0.8,
+		CallVelocityPerMinute:        5,
+		CallVelocityPerHour:          60,
+		CallVelocityPerDay:           300,
+		CallVelocityMaxScore:         0.9,
+		FanOutTimeWindow:             "168h",
+		FanOutThreshold:              20,
+		FanOutMaxScore:               0.8,
+		UnansweredTimeWindow:         "24h",
+		UnansweredRingDropDuration:   3,
+		UnansweredMinCallCount:       5,
+		UnansweredRatioThreshold:     0.7,
+		UnansweredMaxScore:           0.8,
+		RiskyCallerPrefixes:          []string{"00", "+", "1900", "1976"},
+		HomeCallerPrefixes:           []string{"+91", "0091"},
+		TimeOfDayTimeWindow:          "168h",
+		TimeOfDayMinCallCount:        10,
+		TimeOfDayMaxScore:            0.7,
+		PrefixTimezones: map[string]string{
+			"+91": "Asia/Kolkata",
+			"+1":  "America/New_York",
+			"+44": "Europe/London",
+		},
+		DefaultTimezone:            "Asia/Kolkata",
+		NumberSeriesPrefixLength:   5,
+		NumberSeriesMinHistory:     5,
+		NumberSeriesMinSeriesSize:  3,
+		NumberSeriesMaxScore:       0.6,
+		TrustRankDamping:           0.85,
+		TrustRankMaxIterations:     50,
+		TrustRankSeedMinSaves:      5,
+		TrustRankSeedNumbers:       []string{},
+		TrustRankThreshold:         0.3,
+		TrustRankMaxScore:          0.6,
+		KnownSpammerTimeWindow:     "720h",
+		KnownSpammerMinShared:      3,
+		KnownSpammerMatchThreshold: 0.5,
+		KnownSpammerMaxScore:       0.85,
+		CallerIDNameSimilarity:     0.8,
+		SpamNameLexicon: map[string]float64{
+			// English
+			"spam": 1.0, "spammer": 1.0, "fraud": 1.0, "scam": 1.0, "scammer": 1.0, "fake": 0.8, "cheat": 0.9,
+			"don't pick": 0.9, "dont pick": 0.9, "do not pick": 0.9, "don't answer": 0.9, "do not answer": 0.9,
+			"robocall": 0.9, "telemarketer": 0.7, "telemarketing": 0.7, "loan agent": 0.6, "loan offer": 0.6,
+			"credit card offer": 0.6, "insurance agent": 0.4, "call center": 0.4,
+			// Hindi and Hinglish
+			"स्पैम": 1.0, "फ्रॉड": 1.0, "धोखा": 0.9, "धोखेबाज": 1.0, "मत उठाना": 0.9, "mat uthana": 0.9, "mat uthao": 0.9,
+			// Spanish, Portuguese, French, German, Indonesian
+			"estafa": 1.0, "no contestar": 0.9, "golpe": 0.8, "não atender": 0.9, "arnaque": 1.0, "betrug": 1.0, "penipu": 1.0,
+		},
+		ContactLabelMinSavers: 2,
+		ContactLabelMaxScore:  0.8,
+		ScoringStrategy:       "average",
+		RuleWeights: map[string]float64{
+			"spam_report_rule":   2.0,
+			"known_spammer_rule": 2.0,
+			"block_rule":         1.5,
+			"contact_label_rule": 1.5,
+		},
+		SpamVetoes: map[string]float64{
+			"block_rule":         1.0, // The user's own block
+			"known_spammer_rule": 1.0, // Labeled as confirmed spam
+		},
+		SpamPriorRate: 0.1,
+		// Hand-set until fitted from labeled numbers
+		RuleLikelihoodRatios: map[string][2]float64{
+			"spam_report_rule":          {8.0, 0.6},
+			"known_spammer_rule":        {20.0, 0.8},
+			"block_rule":                {6.0, 0.8},
+			"contact_label_rule":        {10.0, 0.8},
+			"contact_count_rule":        {2.0, 0.6},
+			"second_level_contact_rule": {1.5, 0.7},
+			"call_pattern_rule":         {2.0, 0.9},
+			"call_velocity_rule":        {4.0, 0.8},
+			"fan_out_rule":              {3.0, 0.7},
+			"unanswered_call_rule":      {3.0, 0.8},
+			"time_of_day_rule":          {2.0, 0.9},
+			"number_series_rule":        {2.0, 0.9},
+			"trust_rank_rule":           {2.5, 0.6},
+		},
 	}
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 )
 
 // ConfigLoader defines the interface for loading configuration
@@ -30,10 +32,101 @@ func (l *FileConfigLoader) Load() (*Config, error) {
 		cfg.CallDataPath = callDataPath
 	}
 
+	if graphBackend := os.Getenv("GRAPH_BACKEND"); graphBackend != "" {
+		cfg.GraphBackend = graphBackend
+	}
+
+	if graphDataPath := os.Getenv("GRAPH_DATA_PATH"); graphDataPath != "" {
+		cfg.GraphDataPath = graphDataPath
+	}
+
+	if mutationLogPath := os.Getenv("MUTATION_LOG_PATH"); mutationLogPath != "" {
+		cfg.MutationLogPath = mutationLogPath
+	}
+
+	if graphSnapshotPath := os.Getenv("GRAPH_SNAPSHOT_PATH"); graphSnapshotPath != "" {
+		cfg.GraphSnapshotPath = graphSnapshotPath
+	}
+
 	if serverPort := os.Getenv("SERVER_PORT"); serverPort != "" {
 		cfg.ServerPort = serverPort
 	}
 
+	if adminToken := os.Getenv("ADMIN_TOKEN"); adminToken != "" {
+		cfg.AdminToken = adminToken
+	}
+
+	if riskyPrefixes := os.Getenv("RISKY_CALLER_PREFIXES"); riskyPrefixes != "" {
+		cfg.RiskyCallerPrefixes = strings.Split(riskyPrefixes, ",")
+	}
+
+	if homePrefixes := os.Getenv("HOME_CALLER_PREFIXES"); homePrefixes != "" {
+		cfg.HomeCallerPrefixes = strings.Split(homePrefixes, ",")
+	}
+
+	// Format: PREFIX_TIMEZONES="+91=Asia/Kolkata,+1=America/New_York"
+	if prefixTimezones := os.Getenv("PREFIX_TIMEZONES"); prefixTimezones != "" {
+		cfg.PrefixTimezones = make(map[string]string)
+		for _, entry := range strings.Split(prefixTimezones, ",") {
+			if prefix, timezone, ok := strings.Cut(entry, "="); ok {
+				cfg.PrefixTimezones[prefix] = timezone
+			}
+		}
+	}
+
+	if defaultTimezone := os.Getenv("DEFAULT_TIMEZONE"); defaultTimezone != "" {
+		cfg.DefaultTimezone = defaultTimezone
+	}
+
+	if seedNumbers := os.Getenv("TRUST_RANK_SEED_NUMBERS"); seedNumbers != "" {
+		cfg.TrustRankSeedNumbers = strings.Split(seedNumbers, ",")
+	}
+
+	if scoringStrategy := os.Getenv("SCORING_STRATEGY"); scoringStrategy != "" {
+		cfg.ScoringStrategy = scoringStrategy
+	}
+
+	// Format: RULE_WEIGHTS="spam_report_rule=2.0,block_rule=1.5" (replaces the default weights)
+	if ruleWeights := os.Getenv("RULE_WEIGHTS"); ruleWeights != "" {
+		cfg.RuleWeights = parseFloatMap(ruleWeights)
+	}
+
+	// Format: SPAM_VETOES="block_rule=1.0,known_spammer_rule=1.0" (replaces the default vetoes)
+	if spamVetoes := os.Getenv("SPAM_VETOES"); spamVetoes != "" {
+		cfg.SpamVetoes = parseFloatMap(spamVetoes)
+	}
+
+	if priorRate := os.Getenv("SPAM_PRIOR_RATE"); priorRate != "" {
+		if rate, err := strconv.ParseFloat(priorRate, 64); err == nil {
+			cfg.SpamPriorRate = rate
+		}
+	}
+
+	// Format: RULE_LIKELIHOOD_RATIOS="spam_report_rule=8.0:0.6,block_rule=6.0:0.8" (replaces the defaults)
+	if likelihoodRatios := os.Getenv("RULE_LIKELIHOOD_RATIOS"); likelihoodRatios != "" {
+		cfg.RuleLikelihoodRatios = make(map[string][2]float64)
+		for _, entry := range strings.Split(likelihoodRatios, ",") {
+			ruleName, ratios, ok := strings.Cut(entry, "=")
+			if !ok {
+				continue
+			}
+			fired, quiet, ok := strings.Cut(ratios, ":")
+			if !ok {
+				continue
+			}
+			f, errFired := strconv.ParseFloat(strings.TrimSpace(fired), 64)
+			q, errQuiet := strconv.ParseFloat(strings.TrimSpace(quiet), 64)
+			if errFired == nil && errQuiet == nil {
+				cfg.RuleLikelihoodRatios[strings.TrimSpace(ruleName)] = [2]float64{f, q}
+			}
+		}
+	}
+
+	// Format: SPAM_NAME_LEXICON="spam=1.0,loan agent=0.6" (replaces the default lexicon)
+	if lexicon := os.Getenv("SPAM_NAME_LEXICON"); lexicon != "" {
+		cfg.SpamNameLexicon = parseFloatMap(lexicon)
+	}
+
 	// Note: For simplicity, we're using defaults for numeric values
 	// In production, you might want to parse env vars for these too
 
@@ -50,3 +143,16 @@ func Load() *Config {
 	}
 	return cfg
 }
+
+// parseFloatMap parses "key=value,key=value" into a map, skipping malformed entries
+func parseFloatMap(raw string) map[string]float64 {
+	values := make(map[string]float64)
+	for _, entry := range strings.Split(raw, ",") {
+		if key, value, ok := strings.Cut(entry, "="); ok {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				values[strings.TrimSpace(key)] = v
+			}
+		}
+	}
+	return values
+}
@@ -2,7 +2,9 @@ package di
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"credCode/api"
@@ -10,6 +12,7 @@ import (
 	"credCode/repository"
 	"credCode/service"
 	"credCode/service/rules"
+	"credCode/service/scoring"
 )
 
 // Container holds all application dependencies
@@ -17,8 +20,11 @@ type Container struct {
 	config       *config.Config
 	userRepo     repository.UserRepository
 	graphRepo    repository.GraphRepository
+	nameIndex    *repository.NameIndex
 	graphBuilder service.GraphBuilder
+	trustRankJob *service.TrustRankJob
 	spamService  *service.SpamDetectionService
+	callerID     *service.CallerIDService
 	server       *api.Server
 }
 
@@ -30,19 +36,73 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 
 	// Initialize repositories
 	container.userRepo = repository.NewInMemoryUserRepository()
-	container.graphRepo = repository.NewInMemoryGraphRepository()
+	graphRepo, err := repository.NewCayleyGraphRepositoryWithBackend(cfg.GraphBackend, cfg.GraphDataPath)
+	if err != nil {
+		return nil, err
+	}
+	container.graphRepo = graphRepo
+
+	// Recover from the last snapshot and mutation log
+	if cfg.MutationLogPath != "" {
+		log.Println("Recovering graph from snapshot and mutation log...")
+		if err := container.graphRepo.EnableMutationLog(context.Background(), cfg.GraphSnapshotPath, cfg.MutationLogPath); err != nil {
+			container.graphRepo.Close()
+			return nil, err
+		}
+		log.Println("✓ Graph recovered successfully")
+	}
+
+	// Keep the name search index updated from user, contact and node names
+	container.nameIndex = repository.NewNameIndex()
+	container.userRepo.SetNameIndex(container.nameIndex)
+	container.graphRepo.SetNameIndex(container.nameIndex)
 
 	// Initialize graph builder
 	container.graphBuilder = service.NewGraphBuilder()
 
+	// A store that already holds data does not need to be seeded again
+	seedGraph := !container.graphRepo.HasData(context.Background())
+	if !seedGraph {
+		log.Printf("Graph store (%s) already holds data, skipping graph seeding", cfg.GraphBackend)
+	}
+
 	// Load seed data
-	if err := container.loadSeedData(); err != nil {
+	if err := container.loadSeedData(seedGraph); err != nil {
 		return nil, err
 	}
 
+	container.trustRankJob = service.NewTrustRankJob(
+		container.graphRepo,
+		cfg.TrustRankDamping,
+		cfg.TrustRankMaxIterations,
+		cfg.TrustRankSeedMinSaves,
+		cfg.TrustRankSeedNumbers,
+	)
+
 	// Build graph from user data
-	if err := container.buildGraph(); err != nil {
-		return nil, err
+	if seedGraph {
+		// The checkpoint below captures the seeded graph, so logging each edge would only cost fsyncs
+		if cfg.MutationLogPath != "" {
+			if err := container.graphRepo.SuspendMutationLog(); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := container.buildGraph(); err != nil {
+			return nil, err
+		}
+
+		// Score the freshly built graph before it is checkpointed
+		if err := container.computeTrustRank(false); err != nil {
+			return nil, err
+		}
+
+		// Seed data is not logged, so capture it in a checkpoint (which also resumes logging)
+		if cfg.MutationLogPath != "" {
+			if err := container.graphRepo.Checkpoint(context.Background()); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Initialize spam detection service
@@ -50,14 +110,16 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		return nil, err
 	}
 
+	container.callerID = service.NewCallerIDService(container.graphRepo, cfg.CallerIDNameSimilarity)
+
 	// Initialize server
-	container.server = api.NewServer(container.spamService, cfg.ServerPort)
+	container.server = api.NewServer(container.spamService, container.callerID, container.nameIndex, container.graphRepo, container.trustRankJob, cfg.AdminToken, cfg.ServerPort)
 
 	return container, nil
 }
 
-// loadSeedData loads user and call data into repositories
-func (c *Container) loadSeedData() error {
+// loadSeedData loads user data and, if seedGraph is set, call data into repositories
+func (c *Container) loadSeedData(seedGraph bool) error {
 	ctx := context.Background()
 
 	log.Println("Loading user seed data...")
@@ -66,6 +128,10 @@ func (c *Container) loadSeedData() error {
 	}
 	log.Println("✓ User seed data loaded successfully")
 
+	if !seedGraph {
+		return nil
+	}
+
 	log.Println("Loading call data...")
 	if err := c.graphRepo.LoadSeedData(ctx, c.config.CallDataPath); err != nil {
 		return err
@@ -85,10 +151,29 @@ func (c *Container) buildGraph() error {
 	return nil
 }
 
+// computeTrustRank runs the trust rank job, incrementally from the stored scores if requested
+func (c *Container) computeTrustRank(incremental bool) error {
+	log.Println("Computing trust rank...")
+
+	run := c.trustRankJob.Run
+	if incremental {
+		run = c.trustRankJob.RunIncremental
+	}
+
+	result, err := run(context.Background())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Trust rank computed for %d nodes from %d seeds (%d iterations, %d updated)",
+		result.Nodes, result.Seeds, result.Iterations, result.Updated)
+	return nil
+}
+
 // initializeSpamService creates and configures the spam detection service
 func (c *Container) initializeSpamService() error {
-	// Create spam detection service with threshold from config
-	spamService := service.NewSpamDetectionService(c.graphRepo, c.config.SpamThreshold)
+	// Create spam detection service with threshold and scorer from config
+	spamService := service.NewSpamDetectionServiceWithScorer(c.graphRepo, c.config.SpamThreshold, c.newScorer())
 
 	// Register default rules with config values
 	contactRule := rules.NewContactCountRule(
@@ -117,10 +202,190 @@ func (c *Container) initializeSpamService() error {
 	)
 	spamService.RegisterRule(secondLevelRule)
 
+	// Parse report decay and account maturity durations
+	halfLife, err := time.ParseDuration(c.config.SpamReportHalfLife)
+	if err != nil {
+		// Default to 30 days if parsing fails
+		halfLife = 720 * time.Hour
+	}
+
+	matureAge, err := time.ParseDuration(c.config.SpamReportMatureAge)
+	if err != nil {
+		// Default to 90 days if parsing fails
+		matureAge = 2160 * time.Hour
+	}
+
+	spamReportRule := rules.NewSpamReportRule(
+		c.config.SpamReportMaxScore,
+		halfLife,
+		matureAge,
+		c.config.SpamReportTrustedContacts,
+		c.config.SpamReportConsensusTrust,
+	)
+	spamService.RegisterRule(spamReportRule)
+
+	blockRule := rules.NewBlockRule(
+		c.config.BlockThreshold,
+		c.config.BlockMaxScore,
+	)
+	spamService.RegisterRule(blockRule)
+
+	callVelocityRule := rules.NewCallVelocityRule(
+		c.config.CallVelocityPerMinute,
+		c.config.CallVelocityPerHour,
+		c.config.CallVelocityPerDay,
+		c.config.CallVelocityMaxScore,
+	)
+	spamService.RegisterRule(callVelocityRule)
+
+	fanOutWindow, err := time.ParseDuration(c.config.FanOutTimeWindow)
+	if err != nil {
+		// Default to 7 days if parsing fails
+		fanOutWindow = 168 * time.Hour
+	}
+
+	// The fan-out ratio divides by the threshold, so a non-positive one would score NaN
+	if c.config.FanOutThreshold <= 0 {
+		return fmt.Errorf("fan-out threshold must be positive, got %d", c.config.FanOutThreshold)
+	}
+
+	fanOutRule := rules.NewFanOutRule(
+		fanOutWindow,
+		c.config.FanOutThreshold,
+		c.config.FanOutMaxScore,
+	)
+	spamService.RegisterRule(fanOutRule)
+
+	unansweredWindow, err := time.ParseDuration(c.config.UnansweredTimeWindow)
+	if err != nil {
+		// Default to 24 hours if parsing fails
+		unansweredWindow = 24 * time.Hour
+	}
+
+	unansweredRule := rules.NewUnansweredCallRule(
+		unansweredWindow,
+		c.config.UnansweredRingDropDuration,
+		c.config.UnansweredMinCallCount,
+		c.config.UnansweredRatioThreshold,
+		c.config.RiskyCallerPrefixes,
+		c.config.HomeCallerPrefixes,
+		c.config.UnansweredMaxScore,
+	)
+	spamService.RegisterRule(unansweredRule)
+
+	timeOfDayWindow, err := time.ParseDuration(c.config.TimeOfDayTimeWindow)
+	if err != nil {
+		// Default to 7 days if parsing fails
+		timeOfDayWindow = 168 * time.Hour
+	}
+
+	timeOfDayRule := rules.NewTimeOfDayRule(
+		timeOfDayWindow,
+		c.config.TimeOfDayMinCallCount,
+		loadTimezones(c.config.PrefixTimezones),
+		loadTimezone(c.config.DefaultTimezone),
+		c.config.TimeOfDayMaxScore,
+	)
+	spamService.RegisterRule(timeOfDayRule)
+
+	numberSeriesRule := rules.NewNumberSeriesRule(
+		c.config.NumberSeriesPrefixLength,
+		c.config.NumberSeriesMinHistory,
+		c.config.NumberSeriesMinSeriesSize,
+		c.config.NumberSeriesMaxScore,
+	)
+	spamService.RegisterRule(numberSeriesRule)
+
+	trustRankRule := rules.NewTrustRankRule(
+		c.config.TrustRankThreshold,
+		c.config.TrustRankMaxScore,
+	)
+	spamService.RegisterRule(trustRankRule)
+
+	knownSpammerWindow, err := time.ParseDuration(c.config.KnownSpammerTimeWindow)
+	if err != nil {
+		// Default to 30 days if parsing fails
+		knownSpammerWindow = 720 * time.Hour
+	}
+
+	knownSpammerRule := rules.NewKnownSpammerRule(
+		knownSpammerWindow,
+		c.config.KnownSpammerMinShared,
+		c.config.KnownSpammerMatchThreshold,
+		c.config.KnownSpammerMaxScore,
+	)
+	spamService.RegisterRule(knownSpammerRule)
+
+	contactLabelRule := rules.NewContactLabelRule(
+		c.config.SpamNameLexicon,
+		c.config.ContactLabelMinSavers,
+		c.config.ContactLabelMaxScore,
+	)
+	spamService.RegisterRule(contactLabelRule)
+
 	c.spamService = spamService
 	return nil
 }
 
+// newScorer creates the scorer selected by the scoring strategy
+// Vetoes are checked before every strategy, including the default average
+func (c *Container) newScorer() scoring.Scorer {
+	var scorer scoring.Scorer
+	switch c.config.ScoringStrategy {
+	case "weighted":
+		scorer = scoring.NewWeightedScorer(c.config.RuleWeights)
+	case "bayes":
+		ratios := make(map[string]scoring.LikelihoodRatio, len(c.config.RuleLikelihoodRatios))
+		for ruleName, ratio := range c.config.RuleLikelihoodRatios {
+			ratios[ruleName] = scoring.LikelihoodRatio{Fired: ratio[0], Quiet: ratio[1]}
+		}
+		scorer = scoring.NewBayesScorer(c.config.SpamPriorRate, ratios)
+	default:
+		scorer = scoring.NewAverageScorer()
+	}
+
+	// Sorted so that vetoes are checked in a stable order
+	vetoes := make([]scoring.Veto, 0, len(c.config.SpamVetoes))
+	for ruleName, threshold := range c.config.SpamVetoes {
+		vetoes = append(vetoes, scoring.Veto{
+			RuleName:  ruleName,
+			Threshold: threshold,
+			IsSpam:    true,
+		})
+	}
+	sort.Slice(vetoes, func(i, j int) bool {
+		return vetoes[i].RuleName < vetoes[j].RuleName
+	})
+
+	return scoring.NewVetoScorer(scorer, vetoes)
+}
+
+// loadTimezones resolves timezone names by prefix, skipping unknown ones
+func loadTimezones(names map[string]string) map[string]*time.Location {
+	timezones := make(map[string]*time.Location, len(names))
+	for prefix, name := range names {
+		if location := loadTimezone(name); location != nil {
+			timezones[prefix] = location
+		}
+	}
+	return timezones
+}
+
+// loadTimezone resolves a timezone name, returning nil if it is unknown
+func loadTimezone(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("Warning: unknown timezone %q: %v", name, err)
+		return nil
+	}
+	return location
+}
+
+// Close releases resources held by the container
+func (c *Container) Close() error {
+	return c.graphRepo.Close()
+}
+
 // GetServer returns the HTTP server
 func (c *Container) GetServer() *api.Server {
 	return c.server
@@ -136,6 +401,16 @@ func (c *Container) GetGraphRepo() repository.GraphRepository {
 	return c.graphRepo
 }
 
+// GetTrustRankJob returns the trust rank job
+func (c *Container) GetTrustRankJob() *service.TrustRankJob {
+	return c.trustRankJob
+}
+
+// GetCallerIDService returns the caller-ID service
+func (c *Container) GetCallerIDService() *service.CallerIDService {
+	return c.callerID
+}
+
 // GetSpamService returns the spam detection service (for testing)
 func (c *Container) GetSpamService() *service.SpamDetectionService {
 	return c.spamService
@@ -0,0 +1,128 @@
+package di
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"credCode/config"
+	"credCode/models"
+)
+
+// newTestContainer creates a container on the default config with the repository seed data
+func newTestContainer(t *testing.T) *Container {
+	cfg := config.DefaultConfig()
+	cfg.UserSeedDataPath = "../repository/seed_data.json"
+	cfg.CallDataPath = "../call_data.json"
+
+	container, err := NewContainer(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create container: %v", err)
+	}
+	t.Cleanup(func() { container.Close() })
+
+	return container
+}
+
+func TestContainer_DefaultScorer_Vetoes(t *testing.T) {
+	container := newTestContainer(t)
+	graphRepo := container.GetGraphRepo()
+	spamService := container.GetSpamService()
+	ctx := context.Background()
+
+	// The user's own block is a hard verdict
+	if _, err := graphRepo.AddEdgeWithMetadata(ctx, "7379037972", "14022000001", &models.BlockMetadata{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := spamService.DetectSpam("14022000001", "7379037972")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsSpam {
+		t.Errorf("Expected a caller the user blocked to be spam, got score %f", result.AverageScore)
+	}
+
+	// A number labeled as confirmed spam is spam for everyone
+	if err := graphRepo.LabelSpamNumber(ctx, "14022000002", models.SpamLabelSourceManual); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err = spamService.DetectSpam("14022000002", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsSpam {
+		t.Errorf("Expected a labeled number to be spam, got score %f", result.AverageScore)
+	}
+}
+
+func TestContainer_DefaultConfig_BlockedCallerIsSpam(t *testing.T) {
+	container := newTestContainer(t)
+	spamService := container.GetSpamService()
+	ctx := context.Background()
+
+	// A saved contact the user blocked is spam for the user, whatever the graph says about them
+	if _, err := container.GetGraphRepo().AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.BlockMetadata{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := spamService.DetectSpam("9876543210", "7379037972")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsSpam {
+		t.Errorf("Expected a caller the user blocked to be spam, got score %f", result.AverageScore)
+	}
+
+	for _, score := range result.RuleScores {
+		if score.RuleName == "block_rule" && score.Score != 1.0 {
+			t.Errorf("Expected block_rule hard verdict 1.0, got %f", score.Score)
+		}
+	}
+}
+
+func TestContainer_DefaultConfig_TrustedReportsAreSpam(t *testing.T) {
+	container := newTestContainer(t)
+	spamService := container.GetSpamService()
+	ctx := context.Background()
+
+	// A number nobody saved is not spam on that alone
+	result, err := spamService.DetectSpam("9151121479", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsSpam {
+		t.Errorf("Expected an unreported number not to be spam, got score %f", result.AverageScore)
+	}
+
+	// Reported by long-standing users saved by many others
+	for _, reporter := range []string{"7379037972", "9876543210", "1234567890", "9988776655", "6655443322"} {
+		_, err := container.GetGraphRepo().AddEdgeWithMetadata(ctx, reporter, "9151121479", &models.SpamReportMetadata{
+			Category:   "fraud",
+			ReportedAt: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	result, err = spamService.DetectSpam("9151121479", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsSpam {
+		t.Errorf("Expected a number with many trusted reports to be spam, got score %f (%+v)", result.AverageScore, result.RuleScores)
+	}
+}
+
+func TestContainer_RejectsNonPositiveFanOutThreshold(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.UserSeedDataPath = "../repository/seed_data.json"
+	cfg.CallDataPath = "../call_data.json"
+	cfg.FanOutThreshold = 0
+
+	if _, err := NewContainer(cfg); err == nil {
+		t.Error("Expected an error for a zero fan-out threshold")
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// BlockMetadata represents metadata for block edges (blocking user -> blocked number)
+type BlockMetadata struct {
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// EdgeType implements EdgeMetadata interface
+func (bm *BlockMetadata) EdgeType() EdgeType {
+	return EdgeTypeBlock
+}
+
+// UniquePerPair implements PairKeyedMetadata: a user blocks a number at most once
+func (bm *BlockMetadata) UniquePerPair() bool {
+	return true
+}
+
+// OccurredAt implements TimedMetadata
+func (bm *BlockMetadata) OccurredAt() time.Time {
+	return bm.BlockedAt
+}
+
+// ToProperties converts BlockMetadata to a map
+func (bm *BlockMetadata) ToProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"blocked_at": bm.BlockedAt.Format(time.RFC3339),
+	}
+}
+
+// FromProperties deserializes BlockMetadata from a map
+func (bm *BlockMetadata) FromProperties(props map[string]interface{}) error {
+	if blockedAtStr, ok := props["blocked_at"].(string); ok {
+		t, err := time.Parse(time.RFC3339, blockedAtStr)
+		if err != nil {
+			return fmt.Errorf("invalid blocked_at format: %w", err)
+		}
+		bm.BlockedAt = t
+	} else if createdAt, ok := props["created_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+			bm.BlockedAt = t
+		}
+	}
+
+	return nil
+}
+
+// Validate ensures BlockMetadata is valid
+func (bm *BlockMetadata) Validate() error {
+	if bm.BlockedAt.IsZero() {
+		bm.BlockedAt = time.Now() // Default to current time if not set
+	}
+	return nil
+}
+
+// BlockRequest represents the API request to block or unblock a number
+type BlockRequest struct {
+	UserPhoneNumber string `json:"user_phone_number"` // User doing the blocking
+	PhoneNumber     string `json:"phone_number"`      // Blocked number
+}
+
+// BlockedNumber is one entry of a user's block list
+type BlockedNumber struct {
+	PhoneNumber string    `json:"phone_number"`
+	BlockedAt   time.Time `json:"blocked_at"`
+}
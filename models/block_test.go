@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockMetadata_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	bm := &BlockMetadata{BlockedAt: now}
+
+	decoded := &BlockMetadata{}
+	if err := decoded.FromProperties(bm.ToProperties()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !decoded.BlockedAt.Equal(now) {
+		t.Errorf("Expected blocked_at %v, got %v", now, decoded.BlockedAt)
+	}
+	if !decoded.UniquePerPair() {
+		t.Error("Expected blocks to be unique per user and number")
+	}
+}
+
+func TestBlockMetadata_Validate(t *testing.T) {
+	bm := &BlockMetadata{}
+	if err := bm.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bm.BlockedAt.IsZero() {
+		t.Error("Expected blocked_at to default to now")
+	}
+
+	if err := (&BlockMetadata{}).FromProperties(map[string]interface{}{"blocked_at": "yesterday"}); err == nil {
+		t.Error("Expected error for invalid blocked_at")
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+// CallerID is the consensus display name for a phone number, built from what users saved it as
+type CallerID struct {
+	PhoneNumber  string          `json:"phone_number"`
+	Name         string          `json:"name"`         // Most likely display name, empty if nobody saved the number
+	Confidence   float64         `json:"confidence"`   // 0.0 to 1.0
+	SavedBy      int             `json:"saved_by"`     // Users who saved the number with a name
+	Alternatives []NameCandidate `json:"alternatives"` // Other names the number is saved as, most common first
+}
+
+// NameCandidate is one cluster of near-duplicate contact names for a number
+type NameCandidate struct {
+	Name  string  `json:"name"`  // Most common spelling in the cluster
+	Count int     `json:"count"` // Users who saved the number with a name in the cluster
+	Share float64 `json:"share"` // Count as a fraction of all users who saved the number
+}
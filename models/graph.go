@@ -16,8 +16,11 @@ type Node struct {
 type EdgeType string
 
 const (
-	EdgeTypeContact EdgeType = "has_contact"
-	EdgeTypeCall    EdgeType = "call"
+	EdgeTypeContact    EdgeType = "has_contact"
+	EdgeTypeCall       EdgeType = "call"
+	EdgeTypeMessage    EdgeType = "message"
+	EdgeTypeSpamReport EdgeType = "spam_report"
+	EdgeTypeBlock      EdgeType = "block"
 )
 
 // EdgeMetadata is the interface that all edge metadata types must implement
@@ -36,6 +39,17 @@ type EdgeMetadata interface {
 	FromProperties(props map[string]interface{}) error
 }
 
+// PairKeyedMetadata is implemented by metadata whose edges are unique per (from, to) pair
+// Such edges get a stable ID derived from their endpoints
+type PairKeyedMetadata interface {
+	UniquePerPair() bool
+}
+
+// TimedMetadata is implemented by metadata that records when the edge was created
+type TimedMetadata interface {
+	OccurredAt() time.Time
+}
+
 // Edge represents a relationship between two nodes
 type Edge struct {
 	ID        string       `json:"id"`
@@ -75,6 +89,16 @@ func (cm *ContactMetadata) EdgeType() EdgeType {
 	return EdgeTypeContact
 }
 
+// UniquePerPair implements PairKeyedMetadata: a number is saved at most once per user
+func (cm *ContactMetadata) UniquePerPair() bool {
+	return true
+}
+
+// OccurredAt implements TimedMetadata
+func (cm *ContactMetadata) OccurredAt() time.Time {
+	return cm.AddedAt
+}
+
 // ToProperties converts ContactMetadata to a map
 func (cm *ContactMetadata) ToProperties() map[string]interface{} {
 	return map[string]interface{}{
@@ -121,6 +145,11 @@ func (cm *CallMetadata) EdgeType() EdgeType {
 	return EdgeTypeCall
 }
 
+// OccurredAt implements TimedMetadata
+func (cm *CallMetadata) OccurredAt() time.Time {
+	return cm.Timestamp
+}
+
 // ToProperties converts CallMetadata to a map
 func (cm *CallMetadata) ToProperties() map[string]interface{} {
 	return map[string]interface{}{
@@ -181,6 +210,9 @@ func NewEdgeMetadataRegistry() *EdgeMetadataRegistry {
 	// Register default edge types
 	registry.Register(EdgeTypeContact, func() EdgeMetadata { return &ContactMetadata{} })
 	registry.Register(EdgeTypeCall, func() EdgeMetadata { return &CallMetadata{} })
+	registry.Register(EdgeTypeMessage, func() EdgeMetadata { return &MessageMetadata{} })
+	registry.Register(EdgeTypeSpamReport, func() EdgeMetadata { return &SpamReportMetadata{} })
+	registry.Register(EdgeTypeBlock, func() EdgeMetadata { return &BlockMetadata{} })
 
 	return registry
 }
@@ -190,6 +222,12 @@ func (r *EdgeMetadataRegistry) Register(edgeType EdgeType, factory func() EdgeMe
 	r.factories[edgeType] = factory
 }
 
+// IsRegistered reports whether an edge type has been registered
+func (r *EdgeMetadataRegistry) IsRegistered(edgeType EdgeType) bool {
+	_, exists := r.factories[edgeType]
+	return exists
+}
+
 // Deserialize creates and populates edge metadata from properties
 func (r *EdgeMetadataRegistry) Deserialize(edgeType EdgeType, props map[string]interface{}) (EdgeMetadata, error) {
 	factory, exists := r.factories[edgeType]
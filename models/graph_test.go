@@ -314,6 +314,14 @@ func TestEdgeMetadataRegistry_Register(t *testing.T) {
 	if !hasCall {
 		t.Errorf("Expected EdgeTypeCall to be registered")
 	}
+
+	if !registry.IsRegistered(EdgeTypeCall) {
+		t.Errorf("Expected IsRegistered to report EdgeTypeCall")
+	}
+
+	if registry.IsRegistered(EdgeType("unknown")) {
+		t.Errorf("Expected IsRegistered to be false for unknown type")
+	}
 }
 
 func TestEdgeMetadataRegistry_Deserialize(t *testing.T) {
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Sender ID types of a message
+const (
+	SenderIDNumeric      = "numeric"      // sent from a phone number
+	SenderIDAlphanumeric = "alphanumeric" // sent from a header such as "VM-BANKIN"
+)
+
+// Delivery statuses of a message
+const (
+	DeliveryStatusSent      = "sent"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusFailed    = "failed"
+)
+
+// MessageMetadata represents metadata for message (SMS) edges
+// Only content-free features are kept; the message text is never stored
+type MessageMetadata struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Length         int       `json:"length"`          // number of characters
+	LinkCount      int       `json:"link_count"`      // number of URLs in the message
+	SenderIDType   string    `json:"sender_id_type"`  // numeric or alphanumeric
+	DeliveryStatus string    `json:"delivery_status"` // sent, delivered or failed
+}
+
+// EdgeType implements EdgeMetadata interface
+func (mm *MessageMetadata) EdgeType() EdgeType {
+	return EdgeTypeMessage
+}
+
+// OccurredAt implements TimedMetadata
+func (mm *MessageMetadata) OccurredAt() time.Time {
+	return mm.Timestamp
+}
+
+// ToProperties converts MessageMetadata to a map
+func (mm *MessageMetadata) ToProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"timestamp":       mm.Timestamp.Format(time.RFC3339),
+		"length":          mm.Length,
+		"link_count":      mm.LinkCount,
+		"sender_id_type":  mm.SenderIDType,
+		"delivery_status": mm.DeliveryStatus,
+	}
+}
+
+// FromProperties deserializes MessageMetadata from a map
+func (mm *MessageMetadata) FromProperties(props map[string]interface{}) error {
+	if timestampStr, ok := props["timestamp"].(string); ok {
+		t, err := time.Parse(time.RFC3339, timestampStr)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp format: %w", err)
+		}
+		mm.Timestamp = t
+	} else if createdAt, ok := props["created_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+			mm.Timestamp = t
+		}
+	}
+
+	mm.Length = intProperty(props, "length")
+	mm.LinkCount = intProperty(props, "link_count")
+
+	if senderIDType, ok := props["sender_id_type"].(string); ok {
+		mm.SenderIDType = senderIDType
+	}
+
+	if status, ok := props["delivery_status"].(string); ok {
+		mm.DeliveryStatus = status
+	}
+
+	return nil
+}
+
+// Validate ensures MessageMetadata is valid
+func (mm *MessageMetadata) Validate() error {
+	if mm.Length < 0 {
+		return errors.New("message length cannot be negative")
+	}
+	if mm.LinkCount < 0 {
+		return errors.New("link count cannot be negative")
+	}
+
+	switch mm.SenderIDType {
+	case "":
+		mm.SenderIDType = SenderIDNumeric
+	case SenderIDNumeric, SenderIDAlphanumeric:
+	default:
+		return fmt.Errorf("invalid sender ID type: %s", mm.SenderIDType)
+	}
+
+	switch mm.DeliveryStatus {
+	case "":
+		mm.DeliveryStatus = DeliveryStatusDelivered
+	case DeliveryStatusSent, DeliveryStatusDelivered, DeliveryStatusFailed:
+	default:
+		return fmt.Errorf("invalid delivery status: %s", mm.DeliveryStatus)
+	}
+
+	if mm.Timestamp.IsZero() {
+		mm.Timestamp = time.Now() // Default to current time if not set
+	}
+	return nil
+}
+
+// intProperty reads an integer property that may have been decoded as int or float64
+func intProperty(props map[string]interface{}, key string) int {
+	if value, ok := props[key].(int); ok {
+		return value
+	}
+	if value, ok := props[key].(float64); ok {
+		return int(value)
+	}
+	return 0
+}
+
+// MessageRequest represents the API request to record a message
+type MessageRequest struct {
+	From           string    `json:"from"`
+	To             string    `json:"to"`
+	Timestamp      time.Time `json:"timestamp"`
+	Length         int       `json:"length"`
+	LinkCount      int       `json:"link_count"`
+	SenderIDType   string    `json:"sender_id_type,omitempty"`
+	DeliveryStatus string    `json:"delivery_status,omitempty"`
+}
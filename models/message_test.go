@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMessageMetadata_EdgeType(t *testing.T) {
+	mm := &MessageMetadata{}
+	if mm.EdgeType() != EdgeTypeMessage {
+		t.Errorf("Expected EdgeTypeMessage, got %s", mm.EdgeType())
+	}
+}
+
+func TestMessageMetadata_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	mm := &MessageMetadata{
+		Timestamp:      now,
+		Length:         160,
+		LinkCount:      2,
+		SenderIDType:   SenderIDAlphanumeric,
+		DeliveryStatus: DeliveryStatusFailed,
+	}
+
+	decoded := &MessageMetadata{}
+	if err := decoded.FromProperties(mm.ToProperties()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !decoded.Timestamp.Equal(now) {
+		t.Errorf("Expected timestamp %v, got %v", now, decoded.Timestamp)
+	}
+	if decoded.Length != 160 || decoded.LinkCount != 2 {
+		t.Errorf("Expected length 160 and 2 links, got %d and %d", decoded.Length, decoded.LinkCount)
+	}
+	if decoded.SenderIDType != SenderIDAlphanumeric || decoded.DeliveryStatus != DeliveryStatusFailed {
+		t.Errorf("Expected alphanumeric/failed, got %s/%s", decoded.SenderIDType, decoded.DeliveryStatus)
+	}
+}
+
+func TestMessageMetadata_FromProperties_JSONNumbers(t *testing.T) {
+	mm := &MessageMetadata{}
+	err := mm.FromProperties(map[string]interface{}{
+		"length":     float64(42),
+		"link_count": float64(1),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if mm.Length != 42 || mm.LinkCount != 1 {
+		t.Errorf("Expected length 42 and 1 link, got %d and %d", mm.Length, mm.LinkCount)
+	}
+}
+
+func TestMessageMetadata_Validate(t *testing.T) {
+	mm := &MessageMetadata{Length: 10}
+	if err := mm.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Defaults are applied
+	if mm.SenderIDType != SenderIDNumeric {
+		t.Errorf("Expected default sender ID type numeric, got %s", mm.SenderIDType)
+	}
+	if mm.DeliveryStatus != DeliveryStatusDelivered {
+		t.Errorf("Expected default delivery status delivered, got %s", mm.DeliveryStatus)
+	}
+	if mm.Timestamp.IsZero() {
+		t.Error("Expected timestamp to be set")
+	}
+
+	invalid := []*MessageMetadata{
+		{Length: -1},
+		{LinkCount: -1},
+		{SenderIDType: "email"},
+		{DeliveryStatus: "read"},
+	}
+	for _, mm := range invalid {
+		if err := mm.Validate(); err == nil {
+			t.Errorf("Expected validation error for %+v", mm)
+		}
+	}
+}
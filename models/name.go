@@ -0,0 +1,80 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// honorifics are dropped from contact names before they are compared
+var honorifics = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "miss": true, "mx": true, "dr": true, "prof": true,
+	"sir": true, "madam": true, "shri": true, "sri": true, "smt": true, "kumari": true, "ji": true,
+}
+
+// NormaliseName lower-cases a contact name, strips punctuation and honorifics,
+// and sorts its words, so "Mr. Smith,  John" and "john smith" normalise alike
+// Letters of any script are kept
+func NormaliseName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		if !honorifics[word] {
+			kept = append(kept, word)
+		}
+	}
+
+	// A name made only of honorifics ("Sir") is kept as is
+	if len(kept) == 0 {
+		kept = words
+	}
+
+	sort.Strings(kept)
+	return strings.Join(kept, " ")
+}
+
+// NameSimilarity returns 1 - edit distance / length of the longer name, over runes
+func NameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1.0
+	}
+
+	return 1.0 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// EditDistance returns the Levenshtein distance between two strings, over runes
+func EditDistance(a, b string) int {
+	return editDistance([]rune(a), []rune(b))
+}
+
+// editDistance returns the Levenshtein distance between two rune slices
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func TestNormaliseName(t *testing.T) {
+	tests := map[string]string{
+		"John Smith":         "john smith",
+		"  Mr. Smith,  JOHN": "john smith",
+		"Dr John":            "john",
+		"Sharma ji":          "sharma",
+		"Sir":                "sir",
+		"राहुल शर्मा":        "राहुल शर्मा",
+		"...":                "",
+	}
+
+	for name, expected := range tests {
+		if got := NormaliseName(name); got != expected {
+			t.Errorf("NormaliseName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	if s := NameSimilarity("john smith", "john smith"); s != 1.0 {
+		t.Errorf("Expected similarity 1.0 for equal names, got %f", s)
+	}
+
+	// One edit over 10 runes
+	if s := NameSimilarity("john smith", "jon smith"); s < 0.899 || s > 0.901 {
+		t.Errorf("Expected similarity 0.9, got %f", s)
+	}
+
+	if s := NameSimilarity("john smith", "pizza hut"); s > 0.5 {
+		t.Errorf("Expected low similarity for different names, got %f", s)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"john", "jon", 1},
+		{"kitten", "sitting", 3},
+		{"राहुल", "राहल", 1},
+	}
+
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("EditDistance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+// Name sources recorded in the name search index
+const (
+	NameSourceUser    = "user"    // A user's own profile name
+	NameSourceNode    = "node"    // The name on a graph node
+	NameSourceContact = "contact" // A name a user saved a number under
+)
+
+// NameSearchResult is one phone number matching a name search
+type NameSearchResult struct {
+	PhoneNumber string   `json:"phone_number"`
+	Name        string   `json:"name"`    // Best matching name of the number
+	Score       float64  `json:"score"`   // Match quality, 0.0 to 1.0
+	Count       int      `json:"count"`   // Times the name was recorded (e.g., saved by N users)
+	Sources     []string `json:"sources"` // Where the name was recorded ("user", "node", "contact")
+}
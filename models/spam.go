@@ -2,9 +2,10 @@ package models
 
 // SpamScore represents the result of a spam detection rule
 type SpamScore struct {
-	RuleName string  `json:"rule_name"`
-	Score    float64 `json:"score"`  // Score between 0.0 (not spam) and 1.0 (spam)
-	Reason   string  `json:"reason"` // Human-readable reason for the score
+	RuleName      string  `json:"rule_name"`
+	Score         float64 `json:"score"`                    // Score between 0.0 (not spam) and 1.0 (spam)
+	Reason        string  `json:"reason"`                   // Human-readable reason for the score
+	NotApplicable bool    `json:"not_applicable,omitempty"` // Rule had nothing to judge (e.g., no user phone number); its score carries no signal
 }
 
 // SpamDetectionResult represents the final spam detection result
@@ -0,0 +1,18 @@
+package models
+
+// Spam label sources
+const (
+	SpamLabelSourceManual = "manual" // Labeled by an operator
+)
+
+// SpamLabel is a number confirmed as spam by an operator or an external list
+type SpamLabel struct {
+	PhoneNumber string `json:"phone_number"`
+	Source      string `json:"source"` // Where the label came from (e.g., "manual", a regulator's list)
+}
+
+// SpamLabelRequest represents the API request to label or unlabel a confirmed spam number
+type SpamLabelRequest struct {
+	PhoneNumber string `json:"phone_number"`
+	Source      string `json:"source,omitempty"` // Defaults to "manual"
+}
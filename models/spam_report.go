@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Spam report categories
+const (
+	SpamCategoryTelemarketing = "telemarketing"
+	SpamCategoryFraud         = "fraud"
+	SpamCategoryRobocall      = "robocall"
+	SpamCategoryOther         = "other"
+)
+
+// SpamReportMetadata represents metadata for spam report edges (reporter -> reported number)
+type SpamReportMetadata struct {
+	Category   string    `json:"category"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// EdgeType implements EdgeMetadata interface
+func (sm *SpamReportMetadata) EdgeType() EdgeType {
+	return EdgeTypeSpamReport
+}
+
+// UniquePerPair implements PairKeyedMetadata: a user reports a number at most once
+func (sm *SpamReportMetadata) UniquePerPair() bool {
+	return true
+}
+
+// OccurredAt implements TimedMetadata
+func (sm *SpamReportMetadata) OccurredAt() time.Time {
+	return sm.ReportedAt
+}
+
+// ToProperties converts SpamReportMetadata to a map
+func (sm *SpamReportMetadata) ToProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"category":    sm.Category,
+		"reported_at": sm.ReportedAt.Format(time.RFC3339),
+	}
+}
+
+// FromProperties deserializes SpamReportMetadata from a map
+func (sm *SpamReportMetadata) FromProperties(props map[string]interface{}) error {
+	if category, ok := props["category"].(string); ok {
+		sm.Category = category
+	}
+
+	if reportedAtStr, ok := props["reported_at"].(string); ok {
+		t, err := time.Parse(time.RFC3339, reportedAtStr)
+		if err != nil {
+			return fmt.Errorf("invalid reported_at format: %w", err)
+		}
+		sm.ReportedAt = t
+	} else if createdAt, ok := props["created_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+			sm.ReportedAt = t
+		}
+	}
+
+	return nil
+}
+
+// Validate ensures SpamReportMetadata is valid
+func (sm *SpamReportMetadata) Validate() error {
+	switch sm.Category {
+	case SpamCategoryTelemarketing, SpamCategoryFraud, SpamCategoryRobocall, SpamCategoryOther:
+	default:
+		return fmt.Errorf("invalid spam category: %q", sm.Category)
+	}
+
+	if sm.ReportedAt.IsZero() {
+		sm.ReportedAt = time.Now() // Default to current time if not set
+	}
+	return nil
+}
+
+// SpamReportRequest represents the API request to report (or undo a report of) a spam number
+type SpamReportRequest struct {
+	PhoneNumber         string `json:"phone_number"`          // Reported number
+	ReporterPhoneNumber string `json:"reporter_phone_number"` // User filing the report
+	Category            string `json:"category,omitempty"`    // Required when reporting
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpamReportMetadata_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	sm := &SpamReportMetadata{Category: SpamCategoryFraud, ReportedAt: now}
+
+	decoded := &SpamReportMetadata{}
+	if err := decoded.FromProperties(sm.ToProperties()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if decoded.Category != SpamCategoryFraud {
+		t.Errorf("Expected category fraud, got %s", decoded.Category)
+	}
+	if !decoded.ReportedAt.Equal(now) {
+		t.Errorf("Expected reported_at %v, got %v", now, decoded.ReportedAt)
+	}
+	if !decoded.UniquePerPair() {
+		t.Error("Expected spam reports to be unique per reporter and number")
+	}
+}
+
+func TestSpamReportMetadata_Validate(t *testing.T) {
+	sm := &SpamReportMetadata{Category: SpamCategoryRobocall}
+	if err := sm.Validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sm.ReportedAt.IsZero() {
+		t.Error("Expected reported_at to default to now")
+	}
+
+	for _, category := range []string{"", "annoying"} {
+		sm := &SpamReportMetadata{Category: category}
+		if err := sm.Validate(); err == nil {
+			t.Errorf("Expected validation error for category %q", category)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+// callIndexEntry is a call edge held in the call index
+type callIndexEntry struct {
+	edgeID   string
+	from     string
+	to       string
+	metadata models.CallMetadata
+}
+
+// toEdge builds an edge from the entry with its own copy of the metadata
+func (e *callIndexEntry) toEdge() *models.Edge {
+	metadata := e.metadata
+	return &models.Edge{
+		ID:        e.edgeID,
+		From:      e.from,
+		To:        e.to,
+		Type:      models.EdgeTypeCall,
+		Metadata:  &metadata,
+		CreatedAt: metadata.Timestamp,
+	}
+}
+
+// matches checks the answered and duration filters; the time range is handled by the scan
+func (e *callIndexEntry) matches(filters CallFilters) bool {
+	if filters.IsAnswered != nil && e.metadata.IsAnswered != *filters.IsAnswered {
+		return false
+	}
+	if filters.MaxDuration != nil && e.metadata.DurationInSeconds > *filters.MaxDuration {
+		return false
+	}
+	if filters.MinDuration != nil && e.metadata.DurationInSeconds < *filters.MinDuration {
+		return false
+	}
+	return true
+}
+
+// callIndex indexes call edges by participant and timestamp
+// Each phone number has its outgoing and incoming calls sorted by timestamp,
+// so a time range is answered with a binary search and a sequential scan
+type callIndex struct {
+	outgoing map[string][]*callIndexEntry
+	incoming map[string][]*callIndexEntry
+	byID     map[string]*callIndexEntry
+}
+
+// newCallIndex creates an empty call index
+func newCallIndex() *callIndex {
+	return &callIndex{
+		outgoing: make(map[string][]*callIndexEntry),
+		incoming: make(map[string][]*callIndexEntry),
+		byID:     make(map[string]*callIndexEntry),
+	}
+}
+
+// add indexes a call edge, replacing any entry with the same ID
+func (ix *callIndex) add(edgeID, from, to string, metadata *models.CallMetadata) {
+	ix.remove(edgeID)
+
+	entry := &callIndexEntry{
+		edgeID:   edgeID,
+		from:     from,
+		to:       to,
+		metadata: *metadata,
+	}
+
+	ix.outgoing[from] = insertCallEntry(ix.outgoing[from], entry)
+	ix.incoming[to] = insertCallEntry(ix.incoming[to], entry)
+	ix.byID[edgeID] = entry
+}
+
+// remove drops a call edge from the index
+func (ix *callIndex) remove(edgeID string) {
+	entry, ok := ix.byID[edgeID]
+	if !ok {
+		return
+	}
+
+	ix.outgoing[entry.from] = removeCallEntry(ix.outgoing[entry.from], entry)
+	if len(ix.outgoing[entry.from]) == 0 {
+		delete(ix.outgoing, entry.from)
+	}
+
+	ix.incoming[entry.to] = removeCallEntry(ix.incoming[entry.to], entry)
+	if len(ix.incoming[entry.to]) == 0 {
+		delete(ix.incoming, entry.to)
+	}
+
+	delete(ix.byID, edgeID)
+}
+
+// removePhone drops every call edge the phone number participates in
+func (ix *callIndex) removePhone(phoneNumber string) {
+	// Collect the IDs first since remove modifies the slices
+	ids := make([]string, 0)
+	for _, entry := range ix.outgoing[phoneNumber] {
+		ids = append(ids, entry.edgeID)
+	}
+	for _, entry := range ix.incoming[phoneNumber] {
+		ids = append(ids, entry.edgeID)
+	}
+
+	for _, id := range ids {
+		ix.remove(id)
+	}
+}
+
+// scan returns the calls for a phone number in the given direction ("from" or "to")
+// whose timestamp lies within [start, end]; nil bounds are open
+func (ix *callIndex) scan(phoneNumber, direction string, start, end *time.Time, filters CallFilters) []*models.Edge {
+	entries := ix.outgoing[phoneNumber]
+	if direction == "to" {
+		entries = ix.incoming[phoneNumber]
+	}
+
+	i := 0
+	if start != nil {
+		i = sort.Search(len(entries), func(j int) bool {
+			return !entries[j].metadata.Timestamp.Before(*start)
+		})
+	}
+
+	edges := make([]*models.Edge, 0)
+	for ; i < len(entries); i++ {
+		entry := entries[i]
+		if end != nil && entry.metadata.Timestamp.After(*end) {
+			break
+		}
+		if entry.matches(filters) {
+			edges = append(edges, entry.toEdge())
+		}
+	}
+
+	return edges
+}
+
+// callEntryLess orders entries by timestamp, then by edge ID
+func callEntryLess(a, b *callIndexEntry) bool {
+	if !a.metadata.Timestamp.Equal(b.metadata.Timestamp) {
+		return a.metadata.Timestamp.Before(b.metadata.Timestamp)
+	}
+	return a.edgeID < b.edgeID
+}
+
+// insertCallEntry inserts an entry into a sorted slice
+func insertCallEntry(entries []*callIndexEntry, entry *callIndexEntry) []*callIndexEntry {
+	i := sort.Search(len(entries), func(j int) bool {
+		return !callEntryLess(entries[j], entry)
+	})
+
+	entries = append(entries, nil)
+	copy(entries[i+1:], entries[i:])
+	entries[i] = entry
+	return entries
+}
+
+// removeCallEntry removes an entry from a sorted slice
+func removeCallEntry(entries []*callIndexEntry, entry *callIndexEntry) []*callIndexEntry {
+	i := sort.Search(len(entries), func(j int) bool {
+		return !callEntryLess(entries[j], entry)
+	})
+
+	if i < len(entries) && entries[i] == entry {
+		return append(entries[:i], entries[i+1:]...)
+	}
+	return entries
+}
+
+// rebuildCallIndexUnsafe rebuilds the call index from the store (must be called with lock held)
+// Used when the store is opened with existing data or replaced wholesale
+func (r *CayleyGraphRepository) rebuildCallIndexUnsafe(ctx context.Context) error {
+	index := newCallIndex()
+
+	p := cayley.StartPath(r.store).Has(quad.String("type"), quad.String(string(models.EdgeTypeCall)))
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
+
+	for it.Next(ctx) {
+		callID := quad.ToString(r.store.NameOf(it.Result()))
+
+		edge, metadata, err := r.getEdgeWithMetadataUnsafe(ctx, callID)
+		if err != nil {
+			return err
+		}
+
+		// Calls whose participant was deleted are no longer reachable
+		if edge.From == "" || edge.To == "" {
+			continue
+		}
+
+		index.add(callID, edge.From, edge.To, metadata.(*models.CallMetadata))
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	r.calls = index
+	return nil
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+func TestCallIndex_RangeScan(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	// Added out of order to exercise sorted insertion
+	for _, offset := range []int{30, 0, 20, 10, 40} {
+		repo.AddCallEdge("7379037972", "9876543210", true, offset, base.Add(time.Duration(offset)*time.Minute))
+	}
+
+	start := base.Add(10 * time.Minute)
+	end := base.Add(30 * time.Minute)
+
+	calls, count := repo.GetCallsWithFilters(ctx, "7379037972", CallFilters{
+		TimeRangeStart: &start,
+		TimeRangeEnd:   &end,
+	}, "outgoing")
+
+	// Both bounds are inclusive
+	if count != 3 {
+		t.Fatalf("Expected 3 calls, got %d", count)
+	}
+
+	for i, call := range calls {
+		if i > 0 && call.CreatedAt.Before(calls[i-1].CreatedAt) {
+			t.Error("Expected calls in timestamp order")
+		}
+	}
+
+	minDuration := 20
+	_, count = repo.GetCallsWithFilters(ctx, "9876543210", CallFilters{
+		MinDuration:    &minDuration,
+		TimeRangeStart: &start,
+	}, "incoming")
+
+	if count != 3 {
+		t.Errorf("Expected 3 incoming calls with duration >= 20, got %d", count)
+	}
+}
+
+func TestCallIndex_StaysInSync(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	edge, _ := repo.AddCallEdge("7379037972", "9876543210", true, 30, time.Now())
+	repo.AddCallEdge("9876543210", "7379037972", false, 0, time.Now())
+	repo.AddCallEdge("5555555555", "9876543210", true, 10, time.Now())
+
+	// Deleting an edge removes it from the index
+	if err := repo.DeleteEdge(ctx, edge.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, count := repo.GetCallsWithFilters(ctx, "7379037972", CallFilters{}, "outgoing"); count != 0 {
+		t.Errorf("Expected 0 outgoing calls after delete, got %d", count)
+	}
+
+	// Deleting a node removes all its calls
+	if err := repo.DeleteNode(ctx, "7379037972"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, count := repo.GetCallsWithFilters(ctx, "9876543210", CallFilters{}, "both"); count != 1 {
+		t.Errorf("Expected 1 call after node delete, got %d", count)
+	}
+}
+
+func TestCallIndex_RebuiltOnRestore(t *testing.T) {
+	source := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	source.AddCallEdge("7379037972", "9876543210", true, 30, time.Now())
+	source.AddCallEdge("7379037972", "5555555555", true, 10, time.Now())
+
+	var buf bytes.Buffer
+	if err := source.Snapshot(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	target := NewInMemoryGraphRepository()
+	if err := target.Restore(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, count := target.GetCallsWithFilters(ctx, "7379037972", CallFilters{}, "outgoing"); count != 2 {
+		t.Errorf("Expected 2 outgoing calls after restore, got %d", count)
+	}
+}
+
+func TestCallIndex_SeedTimestamps(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	if err := repo.LoadSeedData(ctx, "../call_data.json"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// call_1 only has the edge's created_at
+	edge, err := repo.GetEdge(ctx, "call_1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := time.Date(2024, 4, 22, 23, 27, 16, 0, time.UTC)
+	if !edge.CreatedAt.Equal(expected) {
+		t.Errorf("Expected timestamp %v, got %v", expected, edge.CreatedAt)
+	}
+}
+
+// scanCallsFromStore is the unindexed lookup the call index replaced:
+// every call edge is rebuilt from the store and filtered afterwards
+func scanCallsFromStore(r *CayleyGraphRepository, phoneNumber string, filters CallFilters) int {
+	ctx := context.Background()
+	count := 0
+
+	for _, direction := range []string{"from", "to"} {
+		p := cayley.StartPath(r.store, quad.String(phoneNumber)).In(quad.String(direction)).Has(quad.String("type"), quad.String("call"))
+		it, _ := p.BuildIterator().Optimize()
+
+		for it.Next(ctx) {
+			callID := quad.ToString(r.store.NameOf(it.Result()))
+			edge, _, err := r.getEdgeWithMetadataUnsafe(ctx, callID)
+			if err != nil {
+				continue
+			}
+
+			cm := edge.Metadata.(*models.CallMetadata)
+			if filters.IsAnswered != nil && cm.IsAnswered != *filters.IsAnswered {
+				continue
+			}
+			if filters.MaxDuration != nil && cm.DurationInSeconds > *filters.MaxDuration {
+				continue
+			}
+			if filters.TimeRangeStart != nil && edge.CreatedAt.Before(*filters.TimeRangeStart) {
+				continue
+			}
+			count++
+		}
+		it.Close()
+	}
+
+	return count
+}
+
+// loadCallData loads call_data.json and returns its busiest phone number
+func loadCallData(b *testing.B) (*CayleyGraphRepository, string) {
+	repo := NewInMemoryGraphRepository()
+	if err := repo.LoadSeedData(context.Background(), "../call_data.json"); err != nil {
+		b.Fatalf("Failed to load call data: %v", err)
+	}
+
+	busiest := ""
+	for phone, entries := range repo.calls.outgoing {
+		if busiest == "" || len(entries)+len(repo.calls.incoming[phone]) > len(repo.calls.outgoing[busiest])+len(repo.calls.incoming[busiest]) {
+			busiest = phone
+		}
+	}
+
+	return repo, busiest
+}
+
+// callPatternFilters mirrors the filters used by CallPatternRule
+func callPatternFilters() CallFilters {
+	answered := true
+	maxDuration := 10
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return CallFilters{
+		IsAnswered:     &answered,
+		MaxDuration:    &maxDuration,
+		TimeRangeStart: &start,
+	}
+}
+
+func BenchmarkGetCallsWithFilters_Indexed(b *testing.B) {
+	repo, phone := loadCallData(b)
+	ctx := context.Background()
+	filters := callPatternFilters()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		repo.GetCallsWithFilters(ctx, phone, filters, "both")
+	}
+}
+
+func BenchmarkGetCallsWithFilters_StoreScan(b *testing.B) {
+	repo, phone := loadCallData(b)
+	filters := callPatternFilters()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		repo.mu.RLock()
+		scanCallsFromStore(repo, phone, filters)
+		repo.mu.RUnlock()
+	}
+}
+
+func TestGetCallsWithFilters_MinCallCount(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		repo.AddCallEdge("7379037972", "9876543210", false, 0, time.Now())
+	}
+	repo.AddCallEdge("7379037972", "9876543210", true, 60, time.Now())
+
+	unanswered := false
+	minCount := 3
+	if _, count := repo.GetCallsWithFilters(ctx, "7379037972", CallFilters{IsAnswered: &unanswered, MinCallCount: &minCount}, "outgoing"); count != 3 {
+		t.Errorf("Expected 3 unanswered calls, got %d", count)
+	}
+
+	// Fewer matches than the minimum return nothing
+	minCount = 4
+	calls, count := repo.GetCallsWithFilters(ctx, "7379037972", CallFilters{IsAnswered: &unanswered, MinCallCount: &minCount}, "outgoing")
+	if count != 0 || len(calls) != 0 {
+		t.Errorf("Expected no calls below the minimum count, got %d", count)
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+// Edge directions relative to a node
+const (
+	DirectionOutgoing = "outgoing"
+	DirectionIncoming = "incoming"
+	DirectionBoth     = "both"
+)
+
+// nodeDegree holds the in- and out-degree of a node for one edge type
+type nodeDegree struct {
+	in  int
+	out int
+}
+
+// degreeCounter maintains per-node, per-edge-type degree counts
+type degreeCounter struct {
+	counts map[string]map[models.EdgeType]*nodeDegree
+}
+
+// newDegreeCounter creates an empty degree counter
+func newDegreeCounter() *degreeCounter {
+	return &degreeCounter{
+		counts: make(map[string]map[models.EdgeType]*nodeDegree),
+	}
+}
+
+// degreeFor returns the counter for a node and edge type, creating it if needed
+func (c *degreeCounter) degreeFor(phoneNumber string, edgeType models.EdgeType) *nodeDegree {
+	byType, ok := c.counts[phoneNumber]
+	if !ok {
+		byType = make(map[models.EdgeType]*nodeDegree)
+		c.counts[phoneNumber] = byType
+	}
+
+	degree, ok := byType[edgeType]
+	if !ok {
+		degree = &nodeDegree{}
+		byType[edgeType] = degree
+	}
+
+	return degree
+}
+
+// add counts a new edge from -> to
+func (c *degreeCounter) add(from, to string, edgeType models.EdgeType) {
+	c.degreeFor(from, edgeType).out++
+	c.degreeFor(to, edgeType).in++
+}
+
+// remove uncounts an edge from -> to
+func (c *degreeCounter) remove(from, to string, edgeType models.EdgeType) {
+	if degree := c.degreeFor(from, edgeType); degree.out > 0 {
+		degree.out--
+	}
+	if degree := c.degreeFor(to, edgeType); degree.in > 0 {
+		degree.in--
+	}
+}
+
+// removePhone drops all counts for a node
+func (c *degreeCounter) removePhone(phoneNumber string) {
+	delete(c.counts, phoneNumber)
+}
+
+// get returns the degree of a node for an edge type in the given direction
+func (c *degreeCounter) get(phoneNumber string, edgeType models.EdgeType, direction string) int {
+	degree, ok := c.counts[phoneNumber][edgeType]
+	if !ok {
+		return 0
+	}
+
+	switch direction {
+	case DirectionIncoming:
+		return degree.in
+	case DirectionBoth:
+		return degree.in + degree.out
+	default:
+		return degree.out
+	}
+}
+
+// GetDegree returns the number of edges of a type at a node in O(1)
+// direction: "outgoing", "incoming", or "both"
+func (r *CayleyGraphRepository) GetDegree(ctx context.Context, phoneNumber string, edgeType models.EdgeType, direction string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.degrees.get(phoneNumber, edgeType, direction)
+}
+
+// edgeEndpointsUnsafe returns the endpoints and type of a stored edge (must be called with lock held)
+func (r *CayleyGraphRepository) edgeEndpointsUnsafe(ctx context.Context, edgeID string) (from, to string, edgeType models.EdgeType, ok bool) {
+	values := make(map[string]string, 3)
+
+	for _, pred := range []string{"from", "to", "type"} {
+		p := cayley.StartPath(r.store, quad.String(edgeID)).Out(quad.String(pred))
+		it, _ := p.BuildIterator().Optimize()
+		if it.Next(ctx) {
+			values[pred] = quad.ToString(r.store.NameOf(it.Result()))
+		}
+		it.Close()
+	}
+
+	if values["from"] == "" || values["to"] == "" || values["type"] == "" {
+		return "", "", "", false
+	}
+
+	return values["from"], values["to"], models.EdgeType(values["type"]), true
+}
+
+// rebuildDegreesUnsafe recounts every edge in the store (must be called with lock held)
+func (r *CayleyGraphRepository) rebuildDegreesUnsafe(ctx context.Context) error {
+	degrees := newDegreeCounter()
+
+	// Every edge entity has a "from" quad
+	p := cayley.StartPath(r.store).Has(quad.String("from"))
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
+
+	for it.Next(ctx) {
+		edgeID := quad.ToString(r.store.NameOf(it.Result()))
+		if from, to, edgeType, ok := r.edgeEndpointsUnsafe(ctx, edgeID); ok {
+			degrees.add(from, to, edgeType)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	r.degrees = degrees
+	return nil
+}
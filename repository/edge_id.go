@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"credCode/models"
+)
+
+// crockfordAlphabet is the Crockford base32 alphabet used by ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID: a 48-bit millisecond timestamp followed by 80 random bits,
+// encoded as 26 Crockford base32 characters so that IDs sort by creation time
+func newULID(t time.Time) (string, error) {
+	var raw [16]byte
+
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(raw[:6], ms[2:])
+
+	if _, err := rand.Read(raw[6:]); err != nil {
+		return "", fmt.Errorf("failed to generate edge ID: %w", err)
+	}
+
+	n := new(big.Int).SetBytes(raw[:])
+	base := big.NewInt(32)
+	digit := new(big.Int)
+
+	encoded := make([]byte, 26)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		n.DivMod(n, base, digit)
+		encoded[i] = crockfordAlphabet[digit.Int64()]
+	}
+
+	return string(encoded), nil
+}
+
+// newEdgeID generates a collision-free ID for a new edge, e.g. "call_01HV3K..."
+func newEdgeID(edgeType models.EdgeType) (string, error) {
+	ulid, err := newULID(time.Now())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s_%s", edgeType, ulid), nil
+}
+
+// pairEdgeID returns the stable ID of the edge of a type from -> to
+// Pair-keyed edges (e.g. contacts: a user saves a number at most once) derive their
+// ID from the endpoints, so it is the same across restarts, reloads and snapshots
+func pairEdgeID(edgeType models.EdgeType, from, to string) string {
+	sum := sha256.Sum256([]byte(from + "\x00" + to))
+	return fmt.Sprintf("%s_%s", edgeType, hex.EncodeToString(sum[:16]))
+}
+
+// isPairKeyed reports whether edges with this metadata are unique per (from, to) pair
+func isPairKeyed(metadata models.EdgeMetadata) bool {
+	pk, ok := metadata.(models.PairKeyedMetadata)
+	return ok && pk.UniquePerPair()
+}
+
+// edgeIDFor returns the ID for a new edge: stable for pair-keyed edges, freshly generated otherwise
+func edgeIDFor(from, to string, metadata models.EdgeMetadata) (string, error) {
+	if isPairKeyed(metadata) {
+		return pairEdgeID(metadata.EdgeType(), from, to), nil
+	}
+	return newEdgeID(metadata.EdgeType())
+}
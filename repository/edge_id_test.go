@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestNewULID(t *testing.T) {
+	earlier, err := newULID(time.Now().Add(-time.Second))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	later, _ := newULID(time.Now())
+
+	if len(later) != 26 {
+		t.Errorf("Expected 26 characters, got %d", len(later))
+	}
+
+	// ULIDs sort by creation time
+	if earlier >= later {
+		t.Errorf("Expected %s < %s", earlier, later)
+	}
+}
+
+func TestNewEdgeID_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id, err := newEdgeID(models.EdgeTypeCall)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(id, "call_") {
+			t.Errorf("Expected 'call_' prefix, got '%s'", id)
+		}
+		if seen[id] {
+			t.Fatalf("Duplicate edge ID %s", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestPairEdgeID_Stable(t *testing.T) {
+	id := pairEdgeID(models.EdgeTypeContact, "7379037972", "9876543210")
+
+	if id != pairEdgeID(models.EdgeTypeContact, "7379037972", "9876543210") {
+		t.Error("Expected contact edge ID to be stable")
+	}
+
+	if id == pairEdgeID(models.EdgeTypeContact, "9876543210", "7379037972") {
+		t.Error("Expected contact edge ID to depend on direction")
+	}
+}
@@ -8,8 +8,11 @@ import (
 
 // EdgeRepository defines the interface for edge operations
 type EdgeRepository interface {
+	RegisterEdgeType(edgeType models.EdgeType, factory func() models.EdgeMetadata) error
 	AddEdgeWithMetadata(ctx context.Context, from, to string, metadata models.EdgeMetadata) (*models.Edge, error)
 	GetEdge(ctx context.Context, edgeID string) (*models.Edge, error)
+	FindEdge(ctx context.Context, from, to string, edgeType models.EdgeType) (*models.Edge, error)
 	GetEdgeWithMetadata(ctx context.Context, edgeID string) (*models.Edge, models.EdgeMetadata, error)
+	UpdateEdgeMetadata(ctx context.Context, edgeID string, metadata models.EdgeMetadata) (*models.Edge, error)
 	DeleteEdge(ctx context.Context, edgeID string) error
 }
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestCayleyGraphRepository_MutationLogRecovery(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "graph-snapshot.json.gz")
+	logPath := filepath.Join(dir, "graph.wal")
+
+	repo := NewInMemoryGraphRepository()
+	if err := repo.EnableMutationLog(ctx, snapshotPath, logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	repo.AddNodeWithName(ctx, "7379037972", "John")
+	repo.AddNodeWithName(ctx, "1234567890", "Temp")
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()})
+	call, _ := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.CallMetadata{
+		IsAnswered:        true,
+		DurationInSeconds: 30,
+		Timestamp:         time.Now(),
+	})
+	repo.UpdateEdgeMetadata(ctx, call.ID, &models.CallMetadata{
+		IsAnswered:        true,
+		DurationInSeconds: 45,
+		Timestamp:         time.Now(),
+	})
+	repo.SetNodeProperties(ctx, "7379037972", map[string]interface{}{NodePropertyTrustRank: 0.42})
+	repo.LabelSpamNumber(ctx, "14022000001", "fcc")
+	repo.LabelSpamNumber(ctx, "14022000002", "")
+	repo.UnlabelSpamNumber(ctx, "14022000002")
+	repo.DeleteNode(ctx, "1234567890")
+	repo.Close()
+
+	// A fresh in-memory repository recovers everything from the log
+	recovered := NewInMemoryGraphRepository()
+	if err := recovered.EnableMutationLog(ctx, snapshotPath, logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer recovered.Close()
+
+	node, err := recovered.GetNode(ctx, "7379037972")
+	if err != nil || node.Name != "John" {
+		t.Errorf("Expected node 'John' to be recovered, got %v (err: %v)", node, err)
+	}
+
+	if recovered.NodeExists(ctx, "1234567890") {
+		t.Error("Expected deleted node to stay deleted")
+	}
+
+	if !recovered.IsDirectContact(ctx, "7379037972", "9876543210") {
+		t.Error("Expected contact edge to be recovered")
+	}
+
+	recoveredCall, err := recovered.GetEdge(ctx, call.ID)
+	if err != nil {
+		t.Fatalf("Expected call edge to be recovered, got %v", err)
+	}
+	if cm := recoveredCall.Metadata.(*models.CallMetadata); cm.DurationInSeconds != 45 {
+		t.Errorf("Expected updated duration 45 to be recovered, got %d", cm.DurationInSeconds)
+	}
+
+	if trust, _ := recovered.GetNodeProperty(ctx, "7379037972", NodePropertyTrustRank); trust != 0.42 {
+		t.Errorf("Expected node property 0.42 to be recovered, got %v", trust)
+	}
+
+	if labels := recovered.GetSpamLabels(ctx); len(labels) != 1 || labels[0].PhoneNumber != "14022000001" || labels[0].Source != "fcc" {
+		t.Errorf("Expected spam label of 14022000001 to be recovered, got %v", labels)
+	}
+}
+
+func TestCayleyGraphRepository_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "graph-snapshot.json.gz")
+	logPath := filepath.Join(dir, "graph.wal")
+
+	repo := NewInMemoryGraphRepository()
+	if err := repo.Checkpoint(ctx); err != ErrMutationLogDisabled {
+		t.Errorf("Expected ErrMutationLogDisabled, got %v", err)
+	}
+
+	repo.EnableMutationLog(ctx, snapshotPath, logPath)
+	repo.AddNodeWithName(ctx, "7379037972", "John")
+
+	if err := repo.Checkpoint(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	repo.AddNodeWithName(ctx, "9876543210", "Jane")
+	repo.Close()
+
+	// The log now only holds mutations made after the checkpoint
+	wal, entries, err := OpenMutationLog(logPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wal.Close()
+
+	if len(entries) != 1 {
+		t.Errorf("Expected 1 entry after checkpoint, got %d", len(entries))
+	}
+
+	recovered := NewInMemoryGraphRepository()
+	if err := recovered.EnableMutationLog(ctx, snapshotPath, logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer recovered.Close()
+
+	if !recovered.NodeExists(ctx, "7379037972") || !recovered.NodeExists(ctx, "9876543210") {
+		t.Error("Expected nodes from snapshot and log to be recovered")
+	}
+}
+
+func TestCayleyGraphRepository_SuspendMutationLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "graph-snapshot.json.gz")
+	logPath := filepath.Join(dir, "graph.wal")
+
+	repo := NewInMemoryGraphRepository()
+	if err := repo.SuspendMutationLog(); err != ErrMutationLogDisabled {
+		t.Errorf("Expected ErrMutationLogDisabled, got %v", err)
+	}
+
+	repo.EnableMutationLog(ctx, snapshotPath, logPath)
+	if err := repo.SuspendMutationLog(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	repo.AddNodeWithName(ctx, "7379037972", "John")
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()})
+
+	// Nothing is logged while suspended
+	if info, err := os.Stat(logPath); err != nil || info.Size() != 0 {
+		t.Errorf("Expected an empty mutation log while suspended, got %v (err: %v)", info, err)
+	}
+
+	// The checkpoint captures the unlogged mutations and resumes logging
+	if err := repo.Checkpoint(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	repo.AddNodeWithName(ctx, "1234567890", "Bob")
+	repo.Close()
+
+	wal, entries, err := OpenMutationLog(logPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wal.Close()
+	if len(entries) != 1 {
+		t.Errorf("Expected 1 entry after checkpoint, got %d", len(entries))
+	}
+
+	recovered := NewInMemoryGraphRepository()
+	if err := recovered.EnableMutationLog(ctx, snapshotPath, logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer recovered.Close()
+
+	if !recovered.IsDirectContact(ctx, "7379037972", "9876543210") || !recovered.NodeExists(ctx, "1234567890") {
+		t.Error("Expected suspended mutations from the snapshot and later ones from the log to be recovered")
+	}
+}
@@ -20,6 +20,7 @@ var (
 	ErrNodeNotFound    = errors.New("node not found")
 	ErrEdgeNotFound    = errors.New("edge not found")
 	ErrNodeExists      = errors.New("node already exists")
+	ErrEdgeExists      = errors.New("edge already exists")
 	ErrInvalidEdgeType = errors.New("invalid edge type")
 )
 
@@ -30,6 +31,7 @@ type CallFilters struct {
 	MinDuration    *int       // minimum duration in seconds
 	TimeRangeStart *time.Time // start of time range
 	TimeRangeEnd   *time.Time // end of time range
+	MinCallCount   *int       // return no calls unless at least this many match
 }
 
 // GraphRepository defines the interface for graph operations
@@ -40,30 +42,57 @@ type GraphRepository interface {
 	NodeRepository
 	EdgeRepository
 	QueryRepository
+	SpamLabelRepository
 	SeedDataLoader
+	GraphSnapshotter
+	MutationLogger
+	NameIndexer
+	GraphStore
 }
 
 // CayleyGraphRepository implements GraphRepository using Cayley
 type CayleyGraphRepository struct {
-	store       *cayley.Handle
-	registry    *models.EdgeMetadataRegistry
-	edgeCounter int
-	mu          sync.RWMutex
+	store        *cayley.Handle
+	registry     *models.EdgeMetadataRegistry
+	wal          *MutationLog // nil when mutation logging is disabled
+	snapshotPath string       // checkpoint snapshot written alongside the mutation log
+	walSuspended bool         // mutations go unlogged until the next checkpoint
+	calls        *callIndex   // call edges by participant and timestamp
+	degrees      *degreeCounter
+	numbers      *prefixIndex // node phone numbers in sorted order, for prefix (number series) queries
+	nameIndex    *NameIndex   // nil unless a name index is attached
+	mu           sync.RWMutex
 }
 
 // NewCayleyGraphRepository creates a new Cayley-based graph repository
 func NewCayleyGraphRepository() (*CayleyGraphRepository, error) {
 	// Initialize Cayley in-memory store
-	store, err := cayley.NewMemoryGraph()
+	return NewCayleyGraphRepositoryWithBackend(GraphBackendMemory, "")
+}
+
+// NewCayleyGraphRepositoryWithBackend creates a Cayley-based graph repository on the given backend
+// backend: "memory", "bolt" or "leveldb"; dataPath is the store directory for persistent backends
+func NewCayleyGraphRepositoryWithBackend(backend, dataPath string) (*CayleyGraphRepository, error) {
+	store, err := openGraphStore(backend, dataPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create memory graph: %w", err)
+		return nil, err
+	}
+
+	repo := &CayleyGraphRepository{
+		store:    store,
+		registry: models.NewEdgeMetadataRegistry(),
+		calls:    newCallIndex(),
+		degrees:  newDegreeCounter(),
+		numbers:  newPrefixIndex(),
+	}
+
+	// Persistent stores may already hold edges
+	if err := repo.rebuildIndexesUnsafe(context.Background()); err != nil {
+		store.Close()
+		return nil, err
 	}
 
-	return &CayleyGraphRepository{
-		store:       store,
-		registry:    models.NewEdgeMetadataRegistry(),
-		edgeCounter: 0,
-	}, nil
+	return repo, nil
 }
 
 // NewInMemoryGraphRepository creates a new in-memory graph repository (alias for backwards compatibility)
@@ -75,6 +104,52 @@ func NewInMemoryGraphRepository() *CayleyGraphRepository {
 	return repo
 }
 
+// rebuildIndexesUnsafe rebuilds the call index, degree counters, prefix index and node names in the name index from the store (must be called with lock held)
+func (r *CayleyGraphRepository) rebuildIndexesUnsafe(ctx context.Context) error {
+	if err := r.rebuildCallIndexUnsafe(ctx); err != nil {
+		return fmt.Errorf("failed to build call index: %w", err)
+	}
+
+	if err := r.rebuildDegreesUnsafe(ctx); err != nil {
+		return fmt.Errorf("failed to count degrees: %w", err)
+	}
+
+	if err := r.rebuildPrefixIndexUnsafe(ctx); err != nil {
+		return fmt.Errorf("failed to build prefix index: %w", err)
+	}
+
+	r.rebuildNameIndexUnsafe(ctx)
+
+	return nil
+}
+
+// HasData reports whether the underlying store already holds any quads
+func (r *CayleyGraphRepository) HasData(ctx context.Context) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	it := r.store.QuadsAllIterator()
+	defer it.Close()
+
+	return it.Next(ctx)
+}
+
+// Close closes the mutation log (if enabled) and the underlying store
+func (r *CayleyGraphRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.wal != nil {
+		if err := r.wal.Close(); err != nil {
+			r.store.Close()
+			return fmt.Errorf("failed to close mutation log: %w", err)
+		}
+		r.wal = nil
+	}
+
+	return r.store.Close()
+}
+
 // AddNode adds a new node to the graph (backward compatible - without name)
 func (r *CayleyGraphRepository) AddNode(ctx context.Context, phoneNumber string) error {
 	return r.AddNodeWithName(ctx, phoneNumber, "")
@@ -90,12 +165,34 @@ func (r *CayleyGraphRepository) AddNodeWithName(ctx context.Context, phoneNumber
 		return ErrNodeExists
 	}
 
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:          MutationAddNode,
+		PhoneNumber: phoneNumber,
+		Name:        name,
+	}); err != nil {
+		return err
+	}
+
+	return r.applyAddNodeUnsafe(phoneNumber, name)
+}
+
+// applyAddNodeUnsafe writes the quads for a new node (must be called with lock held)
+func (r *CayleyGraphRepository) applyAddNodeUnsafe(phoneNumber, name string) error {
+	if r.nodeExistsUnsafe(phoneNumber) {
+		return ErrNodeExists
+	}
+
 	// Add node as a quad: phoneNumber -> type -> "node"
 	r.store.AddQuad(quad.Make(phoneNumber, "type", "node", nil))
+	r.numbers.add(phoneNumber)
 
 	// Add name if provided
 	if name != "" {
 		r.store.AddQuad(quad.Make(phoneNumber, "name", name, nil))
+		if r.nameIndex != nil {
+			r.nameIndex.Add(phoneNumber, name, models.NameSourceNode)
+		}
 	}
 
 	return nil
@@ -193,21 +290,61 @@ func (r *CayleyGraphRepository) DeleteNode(ctx context.Context, phoneNumber stri
 		return ErrNodeNotFound
 	}
 
-	// Delete all quads where this phone number is subject or object
-	quadsToDelete := make([]*quad.Quad, 0)
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:          MutationDeleteNode,
+		PhoneNumber: phoneNumber,
+	}); err != nil {
+		return err
+	}
 
-	it := r.store.QuadsAllIterator()
-	defer it.Close()
+	return r.applyDeleteNodeUnsafe(ctx, phoneNumber)
+}
 
+// applyDeleteNodeUnsafe removes a node and all its quads (must be called with lock held)
+func (r *CayleyGraphRepository) applyDeleteNodeUnsafe(ctx context.Context, phoneNumber string) error {
+	if !r.nodeExistsUnsafe(phoneNumber) {
+		return ErrNodeNotFound
+	}
+
+	// Find the edges touching this phone number
+	edgeIDs := make(map[string]bool)
+
+	it := r.store.QuadsAllIterator()
 	for it.Next(ctx) {
 		q := r.store.Quad(it.Result())
 		subject := quad.ToString(q.Subject)
+		predicate := quad.ToString(q.Predicate)
 		object := quad.ToString(q.Object)
 
-		if subject == phoneNumber || object == phoneNumber {
+		if subject == phoneNumber && predicate == "name" && r.nameIndex != nil {
+			r.nameIndex.Remove(phoneNumber, object, models.NameSourceNode)
+		}
+
+		if object == phoneNumber && (predicate == "from" || predicate == "to") {
+			edgeIDs[subject] = true
+		}
+	}
+	it.Close()
+
+	// Delete each edge with all its quads, so no edge is left dangling at the other endpoint
+	for edgeID := range edgeIDs {
+		if err := r.applyDeleteEdgeUnsafe(ctx, edgeID); err != nil && !errors.Is(err, ErrEdgeNotFound) {
+			return err
+		}
+	}
+
+	// Delete the remaining quads where this phone number is subject or object
+	quadsToDelete := make([]*quad.Quad, 0)
+
+	it = r.store.QuadsAllIterator()
+	for it.Next(ctx) {
+		q := r.store.Quad(it.Result())
+		if quad.ToString(q.Subject) == phoneNumber || quad.ToString(q.Object) == phoneNumber {
 			quadsToDelete = append(quadsToDelete, &q)
 		}
 	}
+	it.Close()
 
 	// Delete all found quads
 	for _, q := range quadsToDelete {
@@ -216,6 +353,10 @@ func (r *CayleyGraphRepository) DeleteNode(ctx context.Context, phoneNumber stri
 		}
 	}
 
+	r.calls.removePhone(phoneNumber)
+	r.degrees.removePhone(phoneNumber)
+	r.numbers.remove(phoneNumber)
+
 	return nil
 }
 
@@ -266,77 +407,131 @@ func (r *CayleyGraphRepository) AddCallEdge(from, to string, isAnswered bool, du
 	return r.AddEdgeWithMetadata(ctx, from, to, metadata)
 }
 
+// RegisterEdgeType registers a metadata type so edges of that type can be stored and read back
+// Custom types must be registered before loading seed data or enabling the mutation log
+func (r *CayleyGraphRepository) RegisterEdgeType(edgeType models.EdgeType, factory func() models.EdgeMetadata) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The edge type is used as the adjacency predicate, so it must not clash with a reserved one
+	// or with a node property predicate
+	switch edgeType {
+	case "", "type", "from", "to", "name", NodePropertyTrustRank, spamLabelPredicate:
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidEdgeType, edgeType)
+	}
+
+	r.registry.Register(edgeType, factory)
+	return nil
+}
+
 // AddEdgeWithMetadata is the generic method to add any edge with metadata
 func (r *CayleyGraphRepository) AddEdgeWithMetadata(ctx context.Context, from, to string, metadata models.EdgeMetadata) (*models.Edge, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if !r.registry.IsRegistered(metadata.EdgeType()) {
+		return nil, fmt.Errorf("%w: %s is not registered", ErrInvalidEdgeType, metadata.EdgeType())
+	}
+
 	// Validate metadata
 	if err := metadata.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid metadata: %w", err)
 	}
 
+	edgeID, err := edgeIDFor(from, to, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pair-keyed edges (e.g. contacts) can only be added once; use UpdateEdgeMetadata to change them
+	if r.edgeExistsUnsafe(edgeID) {
+		return nil, ErrEdgeExists
+	}
+
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:         MutationAddEdge,
+		EdgeID:     edgeID,
+		From:       from,
+		To:         to,
+		EdgeType:   metadata.EdgeType(),
+		Properties: metadata.ToProperties(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return r.applyAddEdgeUnsafe(edgeID, from, to, metadata), nil
+}
+
+// applyAddEdgeUnsafe writes the quads for a new edge (must be called with lock held)
+// Every edge type is stored the same way:
+//
+//	from -> <edge type> -> to          (adjacency, e.g. from -> has_contact -> to)
+//	edgeID -> type/from/to -> ...      (the edge entity)
+//	edgeID -> <property> -> value      (one quad per metadata property)
+func (r *CayleyGraphRepository) applyAddEdgeUnsafe(edgeID, from, to string, metadata models.EdgeMetadata) *models.Edge {
 	// Ensure both nodes exist
 	if !r.nodeExistsUnsafe(from) {
 		r.store.AddQuad(quad.Make(from, "type", "node", nil))
+		r.numbers.add(from)
 	}
 	if !r.nodeExistsUnsafe(to) {
 		r.store.AddQuad(quad.Make(to, "type", "node", nil))
+		r.numbers.add(to)
 	}
 
-	properties := metadata.ToProperties()
+	edgeType := metadata.EdgeType()
 
-	// Generate unique edge ID
-	r.edgeCounter++
-	edgeID := fmt.Sprintf("%s_%d", metadata.EdgeType(), r.edgeCounter)
+	// Re-adding an existing edge (e.g. during replay) must not count it twice
+	if !r.edgeExistsUnsafe(edgeID) {
+		r.degrees.add(from, to, edgeType)
+	}
 
-	// Store edge based on type
-	if metadata.EdgeType() == models.EdgeTypeContact {
-		// Contact edges are stored directly: from -> has_contact -> to
-		r.store.AddQuad(quad.Make(from, "has_contact", to, nil))
+	r.store.AddQuad(quad.Make(from, string(edgeType), to, nil))
 
-		// Store metadata as properties on the edge
-		if name, ok := properties["name"].(string); ok && name != "" {
-			// Store contact name: from -> contact_name_to -> name
-			contactKey := fmt.Sprintf("%s_contact_%s", from, to)
-			r.store.AddQuad(quad.Make(contactKey, "name", name, nil))
-			r.store.AddQuad(quad.Make(contactKey, "from", from, nil))
-			r.store.AddQuad(quad.Make(contactKey, "to", to, nil))
-		}
-		if addedAt, ok := properties["added_at"].(string); ok {
-			contactKey := fmt.Sprintf("%s_contact_%s", from, to)
-			r.store.AddQuad(quad.Make(contactKey, "added_at", addedAt, nil))
-		}
-	} else if metadata.EdgeType() == models.EdgeTypeCall {
-		// Call edges are stored with an ID: call_id -> type -> "call"
-		r.store.AddQuad(quad.Make(edgeID, "type", "call", nil))
-		r.store.AddQuad(quad.Make(edgeID, "from", from, nil))
-		r.store.AddQuad(quad.Make(edgeID, "to", to, nil))
-
-		// Store all metadata properties
-		for key, value := range properties {
-			r.store.AddQuad(quad.Make(edgeID, key, value, nil))
-		}
+	r.store.AddQuad(quad.Make(edgeID, "type", string(edgeType), nil))
+	r.store.AddQuad(quad.Make(edgeID, "from", from, nil))
+	r.store.AddQuad(quad.Make(edgeID, "to", to, nil))
+
+	for _, q := range edgePropertyQuads(edgeID, metadata) {
+		r.store.AddQuad(q)
 	}
 
-	// Create edge object
-	edge := &models.Edge{
+	if callMeta, ok := metadata.(*models.CallMetadata); ok {
+		r.calls.add(edgeID, from, to, callMeta)
+	}
+
+	return &models.Edge{
 		ID:        edgeID,
 		From:      from,
 		To:        to,
-		Type:      metadata.EdgeType(),
+		Type:      edgeType,
 		Metadata:  metadata,
-		CreatedAt: time.Now(),
+		CreatedAt: edgeCreatedAt(metadata),
 	}
+}
 
-	// Set CreatedAt from metadata if available
-	if callMeta, ok := metadata.(*models.CallMetadata); ok {
-		edge.CreatedAt = callMeta.Timestamp
-	} else if contactMeta, ok := metadata.(*models.ContactMetadata); ok {
-		edge.CreatedAt = contactMeta.AddedAt
+// edgePropertyQuads returns the property quads stored for an edge's metadata
+// Empty strings are not stored; they read back as the zero value
+func edgePropertyQuads(edgeID string, metadata models.EdgeMetadata) []quad.Quad {
+	quads := make([]quad.Quad, 0)
+
+	for key, value := range metadata.ToProperties() {
+		if value == "" {
+			continue
+		}
+		quads = append(quads, quad.Make(edgeID, key, value, nil))
 	}
 
-	return edge, nil
+	return quads
+}
+
+// edgeCreatedAt returns when the edge was created according to its metadata
+func edgeCreatedAt(metadata models.EdgeMetadata) time.Time {
+	if timed, ok := metadata.(models.TimedMetadata); ok {
+		return timed.OccurredAt()
+	}
+	return time.Now()
 }
 
 // GetEdge retrieves an edge by ID (backward compatible - returns edge with properties map)
@@ -345,168 +540,157 @@ func (r *CayleyGraphRepository) GetEdge(ctx context.Context, edgeID string) (*mo
 	return edge, err
 }
 
+// FindEdge returns the edge of a type from one number to another
+// If there are several (e.g. repeated calls), the first one found is returned
+func (r *CayleyGraphRepository) FindEdge(ctx context.Context, from, to string, edgeType models.EdgeType) (*models.Edge, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	edgeIDs := r.edgeIDsBetweenUnsafe(ctx, from, to, edgeType)
+	if len(edgeIDs) == 0 {
+		return nil, ErrEdgeNotFound
+	}
+
+	edge, _, err := r.getEdgeWithMetadataUnsafe(ctx, edgeIDs[0])
+	return edge, err
+}
+
 // GetEdgeWithMetadata retrieves an edge with its metadata
 func (r *CayleyGraphRepository) GetEdgeWithMetadata(ctx context.Context, edgeID string) (*models.Edge, models.EdgeMetadata, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// Check if edge exists and get its type
-	typePath := cayley.StartPath(r.store, quad.String(edgeID)).Out(quad.String("type"))
-	typeIt, _ := typePath.BuildIterator().Optimize()
-	defer typeIt.Close()
+	return r.getEdgeWithMetadataUnsafe(ctx, edgeID)
+}
 
-	if !typeIt.Next(ctx) {
-		// Try to find contact edge by checking if it's a contact metadata key
-		return r.getContactEdgeByKey(edgeID)
+// getEdgeWithMetadataUnsafe reads an edge and deserializes its metadata through the registry (must be called with lock held)
+func (r *CayleyGraphRepository) getEdgeWithMetadataUnsafe(ctx context.Context, edgeID string) (*models.Edge, models.EdgeMetadata, error) {
+	edge := &models.Edge{
+		ID: edgeID,
 	}
 
-	token := typeIt.Result()
-	edgeTypeStr := quad.ToString(r.store.NameOf(token))
+	properties := make(map[string]interface{})
+
+	for _, q := range r.edgeQuadsUnsafe(ctx, edgeID) {
+		switch predicate := quad.ToString(q.Predicate); predicate {
+		case "type":
+			edge.Type = models.EdgeType(quad.ToString(q.Object))
+		case "from":
+			edge.From = quad.ToString(q.Object)
+		case "to":
+			edge.To = quad.ToString(q.Object)
+		default:
+			value := quad.NativeOf(q.Object)
+			// Integers may be stored as int64; metadata expects int
+			if int64Val, ok := value.(int64); ok {
+				value = int(int64Val)
+			}
+			properties[predicate] = value
+		}
+	}
 
-	if edgeTypeStr == "call" {
-		return r.getCallEdgeWithMetadataUnsafe(edgeID)
+	if edge.Type == "" {
+		return nil, nil, ErrEdgeNotFound
 	}
 
-	return nil, nil, ErrEdgeNotFound
+	metadata, err := r.registry.Deserialize(edge.Type, properties)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to deserialize %s metadata: %w", edge.Type, err)
+	}
+
+	edge.Metadata = metadata
+	edge.CreatedAt = edgeCreatedAt(metadata)
+	return edge, metadata, nil
 }
 
-// getContactEdgeByKey retrieves a contact edge by its metadata key
-func (r *CayleyGraphRepository) getContactEdgeByKey(key string) (*models.Edge, models.EdgeMetadata, error) {
-	ctx := context.TODO()
+// edgeExistsUnsafe checks if any edge is stored under edgeID (must be called with lock held)
+func (r *CayleyGraphRepository) edgeExistsUnsafe(edgeID string) bool {
+	p := cayley.StartPath(r.store, quad.String(edgeID)).Out(quad.String("from"))
 
-	// Check if this is a contact metadata key
-	fromPath := cayley.StartPath(r.store, quad.String(key)).Out(quad.String("from"))
-	fromIt, _ := fromPath.BuildIterator().Optimize()
-	if !fromIt.Next(ctx) {
-		fromIt.Close()
-		return nil, nil, ErrEdgeNotFound
-	}
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
 
-	fromToken := fromIt.Result()
-	from := quad.ToString(r.store.NameOf(fromToken))
-	fromIt.Close()
+	return it.Next(context.TODO())
+}
 
-	toPath := cayley.StartPath(r.store, quad.String(key)).Out(quad.String("to"))
-	toIt, _ := toPath.BuildIterator().Optimize()
-	if !toIt.Next(ctx) {
-		toIt.Close()
-		return nil, nil, ErrEdgeNotFound
-	}
+// UpdateEdgeMetadata replaces the metadata of an existing edge, keeping its ID
+// Only property quads whose values changed are rewritten, in a single transaction
+func (r *CayleyGraphRepository) UpdateEdgeMetadata(ctx context.Context, edgeID string, metadata models.EdgeMetadata) (*models.Edge, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	toToken := toIt.Result()
-	to := quad.ToString(r.store.NameOf(toToken))
-	toIt.Close()
+	if err := metadata.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
 
-	// Get name
-	name := ""
-	namePath := cayley.StartPath(r.store, quad.String(key)).Out(quad.String("name"))
-	nameIt, _ := namePath.BuildIterator().Optimize()
-	if nameIt.Next(ctx) {
-		nameToken := nameIt.Result()
-		name = quad.ToString(r.store.NameOf(nameToken))
+	edge, _, err := r.getEdgeWithMetadataUnsafe(ctx, edgeID)
+	if err != nil {
+		return nil, err
 	}
-	nameIt.Close()
 
-	// Get added_at
-	addedAt := time.Now()
-	addedAtPath := cayley.StartPath(r.store, quad.String(key)).Out(quad.String("added_at"))
-	addedAtIt, _ := addedAtPath.BuildIterator().Optimize()
-	if addedAtIt.Next(ctx) {
-		addedAtToken := addedAtIt.Result()
-		addedAtStr := quad.ToString(r.store.NameOf(addedAtToken))
-		if t, err := time.Parse(time.RFC3339, addedAtStr); err == nil {
-			addedAt = t
-		}
+	if edge.Type != metadata.EdgeType() {
+		return nil, fmt.Errorf("%w: cannot update %s edge with %s metadata", ErrInvalidEdgeType, edge.Type, metadata.EdgeType())
 	}
-	addedAtIt.Close()
 
-	metadata := &models.ContactMetadata{
-		Name:    name,
-		AddedAt: addedAt,
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:         MutationUpdateEdge,
+		EdgeID:     edgeID,
+		EdgeType:   metadata.EdgeType(),
+		Properties: metadata.ToProperties(),
+	}); err != nil {
+		return nil, err
 	}
 
-	edge := &models.Edge{
-		ID:        key,
-		From:      from,
-		To:        to,
-		Type:      models.EdgeTypeContact,
-		Metadata:  metadata,
-		CreatedAt: addedAt,
+	if err := r.applyUpdateEdgeUnsafe(ctx, edgeID, metadata); err != nil {
+		return nil, err
 	}
 
-	return edge, metadata, nil
+	updated, _, err := r.getEdgeWithMetadataUnsafe(ctx, edgeID)
+	return updated, err
 }
 
-// getCallEdgeWithMetadataUnsafe retrieves a call edge with metadata (must be called with lock held)
-func (r *CayleyGraphRepository) getCallEdgeWithMetadataUnsafe(callID string) (*models.Edge, models.EdgeMetadata, error) {
-	ctx := context.TODO()
+// applyUpdateEdgeUnsafe rewrites the changed property quads of an edge (must be called with lock held)
+func (r *CayleyGraphRepository) applyUpdateEdgeUnsafe(ctx context.Context, edgeID string, metadata models.EdgeMetadata) error {
+	from, to, _, ok := r.edgeEndpointsUnsafe(ctx, edgeID)
+	if !ok {
+		return ErrEdgeNotFound
+	}
 
-	edge := &models.Edge{
-		ID:   callID,
-		Type: models.EdgeTypeCall,
+	wanted := make(map[quad.Quad]bool)
+	for _, q := range edgePropertyQuads(edgeID, metadata) {
+		wanted[q] = true
 	}
 
-	properties := make(map[string]interface{})
+	tx := cayley.NewTransaction()
 
-	// Get "from" phone
-	fromPath := cayley.StartPath(r.store, quad.String(callID)).Out(quad.String("from"))
-	fromIt, _ := fromPath.BuildIterator().Optimize()
-	if fromIt.Next(ctx) {
-		token := fromIt.Result()
-		edge.From = quad.ToString(r.store.NameOf(token))
-	}
-	fromIt.Close()
-
-	// Get "to" phone
-	toPath := cayley.StartPath(r.store, quad.String(callID)).Out(quad.String("to"))
-	toIt, _ := toPath.BuildIterator().Optimize()
-	if toIt.Next(ctx) {
-		token := toIt.Result()
-		edge.To = quad.ToString(r.store.NameOf(token))
-	}
-	toIt.Close()
-
-	// Get all properties
-	propertyPredicates := []string{"is_answered", "duration_in_seconds", "timestamp", "created_at"}
-	for _, pred := range propertyPredicates {
-		propPath := cayley.StartPath(r.store, quad.String(callID)).Out(quad.String(pred))
-		propIt, _ := propPath.BuildIterator().Optimize()
-		if propIt.Next(ctx) {
-			token := propIt.Result()
-			val := r.store.NameOf(token)
-			nativeVal := quad.NativeOf(val)
-
-			// Map property names
-			if pred == "duration_in_seconds" {
-				if intVal, ok := nativeVal.(int); ok {
-					properties["duration_in_seconds"] = intVal
-				} else if int64Val, ok := nativeVal.(int64); ok {
-					properties["duration_in_seconds"] = int(int64Val)
-				}
-			} else if pred == "is_answered" {
-				if boolVal, ok := nativeVal.(bool); ok {
-					properties["is_answered"] = boolVal
-				}
-			} else if pred == "timestamp" || pred == "created_at" {
-				if timeStr, ok := nativeVal.(string); ok {
-					properties["timestamp"] = timeStr
-					if t, err := time.Parse(time.RFC3339, timeStr); err == nil {
-						edge.CreatedAt = t
-					}
-				}
-			}
+	// Remove properties that changed or no longer exist; structural quads are kept
+	for _, q := range r.edgeQuadsUnsafe(ctx, edgeID) {
+		switch quad.ToString(q.Predicate) {
+		case "type", "from", "to":
+			continue
 		}
-		propIt.Close()
+
+		if wanted[*q] {
+			delete(wanted, *q)
+			continue
+		}
+		tx.RemoveQuad(*q)
 	}
 
-	// Deserialize metadata
-	metadata, err := r.registry.Deserialize(models.EdgeTypeCall, properties)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to deserialize call metadata: %w", err)
+	for q := range wanted {
+		tx.AddQuad(q)
 	}
 
-	edge.Metadata = metadata
-	return edge, metadata, nil
+	if err := r.store.ApplyTransaction(tx); err != nil {
+		return fmt.Errorf("failed to update edge: %w", err)
+	}
+
+	if callMeta, ok := metadata.(*models.CallMetadata); ok {
+		r.calls.add(edgeID, from, to, callMeta)
+	}
+
+	return nil
 }
 
 // DeleteEdge removes an edge from the graph
@@ -514,34 +698,96 @@ func (r *CayleyGraphRepository) DeleteEdge(ctx context.Context, edgeID string) e
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Find and delete all quads related to this edge
-	it := r.store.QuadsAllIterator()
-	defer it.Close()
+	if len(r.edgeQuadsUnsafe(ctx, edgeID)) == 0 {
+		return ErrEdgeNotFound
+	}
 
-	quadsToDelete := make([]*quad.Quad, 0)
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:     MutationDeleteEdge,
+		EdgeID: edgeID,
+	}); err != nil {
+		return err
+	}
+
+	return r.applyDeleteEdgeUnsafe(ctx, edgeID)
+}
+
+// edgeQuadsUnsafe returns all quads whose subject is the edge ID (must be called with lock held)
+func (r *CayleyGraphRepository) edgeQuadsUnsafe(ctx context.Context, edgeID string) []*quad.Quad {
+	quads := make([]*quad.Quad, 0)
+
+	ref := r.store.ValueOf(quad.String(edgeID))
+	if ref == nil {
+		return quads
+	}
+
+	it := r.store.QuadIterator(quad.Subject, ref)
+	defer it.Close()
 
 	for it.Next(ctx) {
 		q := r.store.Quad(it.Result())
-		subject := quad.ToString(q.Subject)
-
-		if subject == edgeID {
-			quadsToDelete = append(quadsToDelete, &q)
-		}
+		quads = append(quads, &q)
 	}
 
+	return quads
+}
+
+// applyDeleteEdgeUnsafe removes all quads related to an edge (must be called with lock held)
+func (r *CayleyGraphRepository) applyDeleteEdgeUnsafe(ctx context.Context, edgeID string) error {
+	quadsToDelete := r.edgeQuadsUnsafe(ctx, edgeID)
 	if len(quadsToDelete) == 0 {
 		return ErrEdgeNotFound
 	}
 
+	from, to, edgeType, ok := r.edgeEndpointsUnsafe(ctx, edgeID)
+
 	for _, q := range quadsToDelete {
 		if err := r.store.RemoveQuad(*q); err != nil {
 			return fmt.Errorf("failed to remove quad: %w", err)
 		}
 	}
 
+	if ok {
+		r.degrees.remove(from, to, edgeType)
+
+		// The adjacency quad is shared by all edges of this type between the pair
+		if len(r.edgeIDsBetweenUnsafe(ctx, from, to, edgeType)) == 0 {
+			if err := r.store.RemoveQuad(quad.Make(from, string(edgeType), to, nil)); err != nil {
+				return fmt.Errorf("failed to remove quad: %w", err)
+			}
+		}
+	}
+
+	r.calls.remove(edgeID)
+
 	return nil
 }
 
+// edgeIDsBetweenUnsafe returns the IDs of edges of a type from one number to another (must be called with lock held)
+func (r *CayleyGraphRepository) edgeIDsBetweenUnsafe(ctx context.Context, from, to string, edgeType models.EdgeType) []string {
+	p := cayley.StartPath(r.store, quad.String(from)).
+		In(quad.String("from")).
+		Has(quad.String("type"), quad.String(string(edgeType))).
+		Has(quad.String("to"), quad.String(to))
+
+	return r.collectStringsUnsafe(ctx, p)
+}
+
+// collectStringsUnsafe returns the string values of a path's results (must be called with lock held)
+func (r *CayleyGraphRepository) collectStringsUnsafe(ctx context.Context, p *cayley.Path) []string {
+	values := make([]string, 0)
+
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
+
+	for it.Next(ctx) {
+		values = append(values, quad.ToString(r.store.NameOf(it.Result())))
+	}
+
+	return values
+}
+
 // GetUsersWithContact returns all phone numbers that have the given phone number in their contacts
 // Query 1: Give me count or all the users who have saved a phone number in their contact list
 func (r *CayleyGraphRepository) GetUsersWithContact(ctx context.Context, phoneNumber string) ([]string, int) {
@@ -570,62 +816,7 @@ func (r *CayleyGraphRepository) GetOutgoingEdges(ctx context.Context, phoneNumbe
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	edges := make([]*models.Edge, 0)
-
-	if edgeType == models.EdgeTypeContact {
-		// Find all: phoneNumber -has_contact-> ?
-		p := cayley.StartPath(r.store, quad.String(phoneNumber)).Out(quad.String("has_contact"))
-		it, _ := p.BuildIterator().Optimize()
-		defer it.Close()
-
-		for it.Next(ctx) {
-			token := it.Result()
-			toPhone := quad.ToString(r.store.NameOf(token))
-
-			// Try to get metadata
-			contactKey := fmt.Sprintf("%s_contact_%s", phoneNumber, toPhone)
-			edge := &models.Edge{
-				From: phoneNumber,
-				To:   toPhone,
-				Type: models.EdgeTypeContact,
-			}
-
-			// Get metadata if available
-			namePath := cayley.StartPath(r.store, quad.String(contactKey)).Out(quad.String("name"))
-			nameIt, _ := namePath.BuildIterator().Optimize()
-			if nameIt.Next(ctx) {
-				nameToken := nameIt.Result()
-				name := quad.ToString(r.store.NameOf(nameToken))
-				addedAt := time.Now()
-
-				addedAtPath := cayley.StartPath(r.store, quad.String(contactKey)).Out(quad.String("added_at"))
-				addedAtIt, _ := addedAtPath.BuildIterator().Optimize()
-				if addedAtIt.Next(ctx) {
-					addedAtToken := addedAtIt.Result()
-					addedAtStr := quad.ToString(r.store.NameOf(addedAtToken))
-					if t, err := time.Parse(time.RFC3339, addedAtStr); err == nil {
-						addedAt = t
-					}
-				}
-				addedAtIt.Close()
-
-				metadata := &models.ContactMetadata{
-					Name:    name,
-					AddedAt: addedAt,
-				}
-				edge.Metadata = metadata
-				edge.CreatedAt = addedAt
-			}
-			nameIt.Close()
-
-			edges = append(edges, edge)
-		}
-	} else if edgeType == models.EdgeTypeCall {
-		// Find all calls where from = phoneNumber
-		edges = r.getCallsByPhoneUnsafe(phoneNumber, "from")
-	}
-
-	return edges
+	return r.getEdgesByPhoneUnsafe(ctx, phoneNumber, edgeType, "from")
 }
 
 // GetIncomingEdges returns all incoming edges of a specific type to a phone number
@@ -633,78 +824,26 @@ func (r *CayleyGraphRepository) GetIncomingEdges(ctx context.Context, phoneNumbe
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	edges := make([]*models.Edge, 0)
-
-	if edgeType == models.EdgeTypeContact {
-		// Find all: ? -has_contact-> phoneNumber
-		p := cayley.StartPath(r.store, quad.String(phoneNumber)).In(quad.String("has_contact"))
-		it, _ := p.BuildIterator().Optimize()
-		defer it.Close()
-
-		for it.Next(ctx) {
-			token := it.Result()
-			fromPhone := quad.ToString(r.store.NameOf(token))
-
-			edge := &models.Edge{
-				From: fromPhone,
-				To:   phoneNumber,
-				Type: models.EdgeTypeContact,
-			}
-
-			// Try to get metadata
-			contactKey := fmt.Sprintf("%s_contact_%s", fromPhone, phoneNumber)
-			namePath := cayley.StartPath(r.store, quad.String(contactKey)).Out(quad.String("name"))
-			nameIt, _ := namePath.BuildIterator().Optimize()
-			if nameIt.Next(ctx) {
-				nameToken := nameIt.Result()
-				name := quad.ToString(r.store.NameOf(nameToken))
-				addedAt := time.Now()
-
-				addedAtPath := cayley.StartPath(r.store, quad.String(contactKey)).Out(quad.String("added_at"))
-				addedAtIt, _ := addedAtPath.BuildIterator().Optimize()
-				if addedAtIt.Next(ctx) {
-					addedAtToken := addedAtIt.Result()
-					addedAtStr := quad.ToString(r.store.NameOf(addedAtToken))
-					if t, err := time.Parse(time.RFC3339, addedAtStr); err == nil {
-						addedAt = t
-					}
-				}
-				addedAtIt.Close()
-
-				metadata := &models.ContactMetadata{
-					Name:    name,
-					AddedAt: addedAt,
-				}
-				edge.Metadata = metadata
-				edge.CreatedAt = addedAt
-			}
-			nameIt.Close()
+	return r.getEdgesByPhoneUnsafe(ctx, phoneNumber, edgeType, "to")
+}
 
-			edges = append(edges, edge)
-		}
-	} else if edgeType == models.EdgeTypeCall {
-		// Find all calls where to = phoneNumber
-		edges = r.getCallsByPhoneUnsafe(phoneNumber, "to")
+// getEdgesByPhoneUnsafe retrieves edges of a type by phone number (must be called with lock held)
+// direction: "from" for outgoing edges, "to" for incoming edges
+func (r *CayleyGraphRepository) getEdgesByPhoneUnsafe(ctx context.Context, phoneNumber string, edgeType models.EdgeType, direction string) []*models.Edge {
+	// Calls are served from the call index
+	if edgeType == models.EdgeTypeCall {
+		return r.calls.scan(phoneNumber, direction, nil, nil, CallFilters{})
 	}
 
-	return edges
-}
-
-// getCallsByPhoneUnsafe retrieves call edges by phone number (must be called with lock held)
-func (r *CayleyGraphRepository) getCallsByPhoneUnsafe(phoneNumber, direction string) []*models.Edge {
-	ctx := context.TODO()
 	edges := make([]*models.Edge, 0)
 
-	// Find all call IDs where direction = phoneNumber
-	p := cayley.StartPath(r.store, quad.String(phoneNumber)).In(quad.String(direction)).Has(quad.String("type"), quad.String("call"))
-
-	it, _ := p.BuildIterator().Optimize()
-	defer it.Close()
+	// Find all edge IDs where direction = phoneNumber
+	p := cayley.StartPath(r.store, quad.String(phoneNumber)).
+		In(quad.String(direction)).
+		Has(quad.String("type"), quad.String(string(edgeType)))
 
-	for it.Next(ctx) {
-		token := it.Result()
-		callID := quad.ToString(r.store.NameOf(token))
-		if edge, _, err := r.getCallEdgeWithMetadataUnsafe(callID); err == nil {
+	for _, edgeID := range r.collectStringsUnsafe(ctx, p) {
+		if edge, _, err := r.getEdgeWithMetadataUnsafe(ctx, edgeID); err == nil {
 			edges = append(edges, edge)
 		}
 	}
@@ -715,92 +854,32 @@ func (r *CayleyGraphRepository) getCallsByPhoneUnsafe(phoneNumber, direction str
 // GetCallsWithFilters returns call edges with applied filters
 // Query 2: How many calls a phone number is making with filters
 // direction: "outgoing", "incoming", or "both"
+// Served from the call index: the time range is a range scan over the phone
+// number's calls, and the answered/duration filters are applied during the scan
 func (r *CayleyGraphRepository) GetCallsWithFilters(ctx context.Context, phoneNumber string, filters CallFilters, direction string) ([]*models.Edge, int) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var candidateEdges []*models.Edge
+	start, end := filters.TimeRangeStart, filters.TimeRangeEnd
 
-	// Gather candidate edges based on direction
+	var edges []*models.Edge
 	switch direction {
-	case "outgoing":
-		candidateEdges = r.getCallsByPhoneUnsafe(phoneNumber, "from")
-	case "incoming":
-		candidateEdges = r.getCallsByPhoneUnsafe(phoneNumber, "to")
-	case "both":
-		candidateEdges = append(
-			r.getCallsByPhoneUnsafe(phoneNumber, "from"),
-			r.getCallsByPhoneUnsafe(phoneNumber, "to")...,
+	case DirectionIncoming:
+		edges = r.calls.scan(phoneNumber, "to", start, end, filters)
+	case DirectionBoth:
+		edges = append(
+			r.calls.scan(phoneNumber, "from", start, end, filters),
+			r.calls.scan(phoneNumber, "to", start, end, filters)...,
 		)
 	default:
-		candidateEdges = r.getCallsByPhoneUnsafe(phoneNumber, "from")
-	}
-
-	// Apply filters
-	filteredEdges := make([]*models.Edge, 0)
-	for _, edge := range candidateEdges {
-		if r.matchesCallFilters(edge, filters) {
-			filteredEdges = append(filteredEdges, edge)
-		}
-	}
-
-	return filteredEdges, len(filteredEdges)
-}
-
-// matchesCallFilters checks if an edge matches the given call filters
-func (r *CayleyGraphRepository) matchesCallFilters(edge *models.Edge, filters CallFilters) bool {
-	if edge.Type != models.EdgeTypeCall {
-		return false
-	}
-
-	// Use metadata if available, otherwise fall back to properties
-	var callMeta *models.CallMetadata
-	if edge.Metadata != nil {
-		if cm, ok := edge.Metadata.(*models.CallMetadata); ok {
-			callMeta = cm
-		}
-	}
-
-	// Fallback to parsing properties for backward compatibility
-	if callMeta == nil {
-		props := edge.GetProperties()
-		if meta, err := r.registry.Deserialize(models.EdgeTypeCall, props); err == nil {
-			if cm, ok := meta.(*models.CallMetadata); ok {
-				callMeta = cm
-			}
-		}
-	}
-
-	if callMeta == nil {
-		return false
+		edges = r.calls.scan(phoneNumber, "from", start, end, filters)
 	}
 
-	// Filter by IsAnswered
-	if filters.IsAnswered != nil && callMeta.IsAnswered != *filters.IsAnswered {
-		return false
+	if filters.MinCallCount != nil && len(edges) < *filters.MinCallCount {
+		return []*models.Edge{}, 0
 	}
 
-	// Filter by MaxDuration
-	if filters.MaxDuration != nil && callMeta.DurationInSeconds > *filters.MaxDuration {
-		return false
-	}
-
-	// Filter by MinDuration
-	if filters.MinDuration != nil && callMeta.DurationInSeconds < *filters.MinDuration {
-		return false
-	}
-
-	// Filter by TimeRangeStart
-	if filters.TimeRangeStart != nil && edge.CreatedAt.Before(*filters.TimeRangeStart) {
-		return false
-	}
-
-	// Filter by TimeRangeEnd
-	if filters.TimeRangeEnd != nil && edge.CreatedAt.After(*filters.TimeRangeEnd) {
-		return false
-	}
-
-	return true
+	return edges, len(edges)
 }
 
 // IsDirectContact checks if callerPhone is in userPhone's direct contacts (level 1)
@@ -884,6 +963,40 @@ func (r *CayleyGraphRepository) GetSecondLevelContactCount(ctx context.Context,
 	return count
 }
 
+// GetReciprocatedNumbers returns which of the given numbers reciprocate phoneNumber:
+// they have called it back or have it saved as a contact
+// This is the batched form of IsDirectContact plus a callback check: one contact query
+// and one call index lookup, instead of a lookup per number
+func (r *CayleyGraphRepository) GetReciprocatedNumbers(ctx context.Context, phoneNumber string, numbers []string) map[string]bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reciprocated := make(map[string]bool)
+	if len(numbers) == 0 {
+		return reciprocated
+	}
+
+	// Query: ? -has_contact-> phoneNumber
+	p := cayley.StartPath(r.store, quad.String(phoneNumber)).In(quad.String(string(models.EdgeTypeContact)))
+	for _, saver := range r.collectStringsUnsafe(ctx, p) {
+		reciprocated[saver] = true
+	}
+
+	// Everyone who has called phoneNumber
+	for _, entry := range r.calls.incoming[phoneNumber] {
+		reciprocated[entry.from] = true
+	}
+
+	result := make(map[string]bool, len(numbers))
+	for _, number := range numbers {
+		if reciprocated[number] {
+			result[number] = true
+		}
+	}
+
+	return result
+}
+
 // LoadSeedData loads graph seed data from a JSON file
 func (r *CayleyGraphRepository) LoadSeedData(ctx context.Context, filePath string) error {
 	r.mu.Lock()
@@ -909,56 +1022,54 @@ func (r *CayleyGraphRepository) LoadSeedData(ctx context.Context, filePath strin
 	for _, node := range seedData.Nodes {
 		if !r.nodeExistsUnsafe(node.PhoneNumber) {
 			r.store.AddQuad(quad.Make(node.PhoneNumber, "type", "node", nil))
+			r.numbers.add(node.PhoneNumber)
 			if node.Name != "" {
 				r.store.AddQuad(quad.Make(node.PhoneNumber, "name", node.Name, nil))
+				if r.nameIndex != nil {
+					r.nameIndex.Add(node.PhoneNumber, node.Name, models.NameSourceNode)
+				}
 			}
 		}
 	}
 
 	// Load edges
 	for _, edgeJSON := range seedData.Edges {
-		// Ensure nodes exist
-		if !r.nodeExistsUnsafe(edgeJSON.From) {
-			r.store.AddQuad(quad.Make(edgeJSON.From, "type", "node", nil))
+		if !r.registry.IsRegistered(edgeJSON.Type) {
+			return fmt.Errorf("failed to load edge %s: %w: %s is not registered", edgeJSON.ID, ErrInvalidEdgeType, edgeJSON.Type)
 		}
-		if !r.nodeExistsUnsafe(edgeJSON.To) {
-			r.store.AddQuad(quad.Make(edgeJSON.To, "type", "node", nil))
+
+		// Seed edges may only carry the edge's created_at; metadata falls back to it
+		properties := make(map[string]interface{}, len(edgeJSON.Properties)+1)
+		for key, value := range edgeJSON.Properties {
+			properties[key] = value
+		}
+		if _, ok := properties["created_at"]; !ok && !edgeJSON.CreatedAt.IsZero() {
+			properties["created_at"] = edgeJSON.CreatedAt.Format(time.RFC3339)
 		}
 
-		// Deserialize edge using registry
-		edge, err := edgeJSON.ToEdge(r.registry)
+		// Deserialize metadata using registry
+		metadata, err := r.registry.Deserialize(edgeJSON.Type, properties)
 		if err != nil {
 			return fmt.Errorf("failed to deserialize edge %s: %w", edgeJSON.ID, err)
 		}
+		if err := metadata.Validate(); err != nil {
+			return fmt.Errorf("invalid metadata for edge %s: %w", edgeJSON.ID, err)
+		}
 
-		// Store edge based on type
-		if edge.Type == models.EdgeTypeContact {
-			r.store.AddQuad(quad.Make(edge.From, "has_contact", edge.To, nil))
-
-			// Store contact metadata if available
-			if contactMeta, ok := edge.Metadata.(*models.ContactMetadata); ok {
-				contactKey := fmt.Sprintf("%s_contact_%s", edge.From, edge.To)
-				if contactMeta.Name != "" {
-					r.store.AddQuad(quad.Make(contactKey, "name", contactMeta.Name, nil))
-					r.store.AddQuad(quad.Make(contactKey, "from", edge.From, nil))
-					r.store.AddQuad(quad.Make(contactKey, "to", edge.To, nil))
-				}
-				if !contactMeta.AddedAt.IsZero() {
-					r.store.AddQuad(quad.Make(contactKey, "added_at", contactMeta.AddedAt.Format(time.RFC3339), nil))
-				}
-			}
-		} else if edge.Type == models.EdgeTypeCall {
-			// Store call edge
-			r.store.AddQuad(quad.Make(edge.ID, "type", "call", nil))
-			r.store.AddQuad(quad.Make(edge.ID, "from", edge.From, nil))
-			r.store.AddQuad(quad.Make(edge.ID, "to", edge.To, nil))
-
-			// Store all metadata properties
-			props := edge.GetProperties()
-			for key, value := range props {
-				r.store.AddQuad(quad.Make(edge.ID, key, value, nil))
+		// Seed IDs are kept, except for pair-keyed edges which use their stable ID
+		edgeID := edgeJSON.ID
+		if edgeID == "" || isPairKeyed(metadata) {
+			if edgeID, err = edgeIDFor(edgeJSON.From, edgeJSON.To, metadata); err != nil {
+				return err
 			}
 		}
+
+		// Never let a seed edge merge with an existing edge
+		if r.edgeExistsUnsafe(edgeID) {
+			return fmt.Errorf("failed to load edge %s (%s -> %s): %w", edgeID, edgeJSON.From, edgeJSON.To, ErrEdgeExists)
+		}
+
+		r.applyAddEdgeUnsafe(edgeID, edgeJSON.From, edgeJSON.To, metadata)
 	}
 
 	return nil
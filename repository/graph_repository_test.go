@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -157,6 +161,48 @@ func TestCayleyGraphRepository_DeleteNode(t *testing.T) {
 	}
 }
 
+func TestCayleyGraphRepository_DeleteNode_RemovesEdges(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Jane", AddedAt: now})
+	repo.AddEdgeWithMetadata(ctx, "9876543210", "7379037972", &models.ContactMetadata{Name: "John", AddedAt: now})
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.MessageMetadata{Timestamp: now, Length: 20})
+	repo.AddEdgeWithMetadata(ctx, "9876543210", "7379037972", &models.SpamReportMetadata{Category: models.SpamCategoryRobocall, ReportedAt: now})
+	repo.AddEdgeWithMetadata(ctx, "9876543210", "7379037972", &models.BlockMetadata{BlockedAt: now})
+	repo.AddCallEdge("7379037972", "9876543210", true, 30, now)
+
+	if err := repo.DeleteNode(ctx, "7379037972"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// No edge of the deleted node survives at the other endpoint
+	for _, edgeType := range []models.EdgeType{models.EdgeTypeContact, models.EdgeTypeMessage, models.EdgeTypeSpamReport, models.EdgeTypeBlock, models.EdgeTypeCall} {
+		if edges := repo.GetIncomingEdges(ctx, "9876543210", edgeType); len(edges) != 0 {
+			t.Errorf("Expected no incoming %s edges, got %d", edgeType, len(edges))
+		}
+		if edges := repo.GetOutgoingEdges(ctx, "9876543210", edgeType); len(edges) != 0 {
+			t.Errorf("Expected no outgoing %s edges, got %d", edgeType, len(edges))
+		}
+		if degree := repo.GetDegree(ctx, "9876543210", edgeType, DirectionBoth); degree != 0 {
+			t.Errorf("Expected %s degree 0, got %d", edgeType, degree)
+		}
+	}
+
+	// Degrees rebuilt from the store agree
+	var buf bytes.Buffer
+	repo.Snapshot(ctx, &buf)
+
+	restored := NewInMemoryGraphRepository()
+	if err := restored.Restore(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if degree := restored.GetDegree(ctx, "9876543210", models.EdgeTypeContact, DirectionBoth); degree != 0 {
+		t.Errorf("Expected contact degree 0 after restore, got %d", degree)
+	}
+}
+
 func TestCayleyGraphRepository_AddEdgeWithMetadata_Contact(t *testing.T) {
 	repo := NewInMemoryGraphRepository()
 	ctx := context.Background()
@@ -426,3 +472,415 @@ func TestCayleyGraphRepository_GetIncomingEdges(t *testing.T) {
 	}
 }
 
+func TestCayleyGraphRepository_PersistentBackend(t *testing.T) {
+	ctx := context.Background()
+	dataPath := t.TempDir()
+
+	repo, err := NewCayleyGraphRepositoryWithBackend(GraphBackendLevelDB, dataPath)
+	if err != nil {
+		t.Fatalf("Unexpected error creating repository: %v", err)
+	}
+
+	if repo.HasData(ctx) {
+		t.Error("Expected new store to be empty")
+	}
+
+	repo.AddNodeWithName(ctx, "7379037972", "John Doe")
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Unexpected error closing repository: %v", err)
+	}
+
+	// Reopen the same store
+	repo, err = NewCayleyGraphRepositoryWithBackend(GraphBackendLevelDB, dataPath)
+	if err != nil {
+		t.Fatalf("Unexpected error reopening repository: %v", err)
+	}
+	defer repo.Close()
+
+	if !repo.HasData(ctx) {
+		t.Error("Expected reopened store to hold data")
+	}
+
+	node, err := repo.GetNode(ctx, "7379037972")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if node.Name != "John Doe" {
+		t.Errorf("Expected name 'John Doe', got '%s'", node.Name)
+	}
+}
+
+func TestCayleyGraphRepository_UnsupportedBackend(t *testing.T) {
+	_, err := NewCayleyGraphRepositoryWithBackend("postgres", t.TempDir())
+	if !errors.Is(err, ErrUnsupportedBackend) {
+		t.Errorf("Expected ErrUnsupportedBackend, got %v", err)
+	}
+
+	_, err = NewCayleyGraphRepositoryWithBackend(GraphBackendBolt, "")
+	if err == nil {
+		t.Error("Expected error for missing data path")
+	}
+}
+
+func TestCayleyGraphRepository_EdgeIDsAfterSeedLoad(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	seedPath := filepath.Join(t.TempDir(), "seed.json")
+	os.WriteFile(seedPath, []byte(`{
+		"nodes": [{"phone_number": "7379037972"}, {"phone_number": "9876543210"}],
+		"edges": [
+			{"id": "call_1", "from": "7379037972", "to": "9876543210", "type": "call",
+			 "properties": {"duration_in_seconds": 300, "is_answered": true}},
+			{"id": "contact_1", "from": "9876543210", "to": "7379037972", "type": "has_contact",
+			 "properties": {"name": "John", "added_at": "2024-01-15T10:00:00Z"}}
+		]
+	}`), 0644)
+
+	if err := repo.LoadSeedData(ctx, seedPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A call added after the seed load must not merge with the seeded call
+	edge, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.CallMetadata{
+		IsAnswered:        false,
+		DurationInSeconds: 5,
+		Timestamp:         time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if edge.ID == "call_1" {
+		t.Error("Expected new call to get a fresh ID")
+	}
+
+	seeded, err := repo.GetEdge(ctx, "call_1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cm := seeded.Metadata.(*models.CallMetadata); cm.DurationInSeconds != 300 {
+		t.Errorf("Expected seeded call to keep duration 300, got %d", cm.DurationInSeconds)
+	}
+
+	// Loading the same seed again must not merge calls under an existing ID
+	if err := repo.LoadSeedData(ctx, seedPath); !errors.Is(err, ErrEdgeExists) {
+		t.Errorf("Expected ErrEdgeExists, got %v", err)
+	}
+
+	// Contact edges are reachable by their stable ID
+	incoming := repo.GetIncomingEdges(ctx, "7379037972", models.EdgeTypeContact)
+	if len(incoming) != 1 {
+		t.Fatalf("Expected 1 incoming contact edge, got %d", len(incoming))
+	}
+
+	contact, err := repo.GetEdge(ctx, incoming[0].ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cm := contact.Metadata.(*models.ContactMetadata); cm.Name != "John" {
+		t.Errorf("Expected contact name 'John', got '%s'", cm.Name)
+	}
+}
+
+func TestCayleyGraphRepository_GetDegree(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	meta := &models.ContactMetadata{Name: "Caller", AddedAt: time.Now()}
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "5555555555", meta)
+	repo.AddEdgeWithMetadata(ctx, "9876543210", "5555555555", meta)
+	repo.AddEdgeWithMetadata(ctx, "5555555555", "7379037972", meta)
+
+	// Re-adding an existing contact is rejected and not counted twice
+	if _, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "5555555555", meta); err != ErrEdgeExists {
+		t.Errorf("Expected ErrEdgeExists, got %v", err)
+	}
+
+	call, _ := repo.AddCallEdge("5555555555", "9876543210", true, 30, time.Now())
+
+	if degree := repo.GetDegree(ctx, "5555555555", models.EdgeTypeContact, DirectionIncoming); degree != 2 {
+		t.Errorf("Expected contact in-degree 2, got %d", degree)
+	}
+	if degree := repo.GetDegree(ctx, "5555555555", models.EdgeTypeContact, DirectionOutgoing); degree != 1 {
+		t.Errorf("Expected contact out-degree 1, got %d", degree)
+	}
+	if degree := repo.GetDegree(ctx, "5555555555", models.EdgeTypeCall, DirectionBoth); degree != 1 {
+		t.Errorf("Expected call degree 1, got %d", degree)
+	}
+
+	// Deleting edges and nodes updates the counters
+	repo.DeleteEdge(ctx, call.ID)
+	if degree := repo.GetDegree(ctx, "9876543210", models.EdgeTypeCall, DirectionIncoming); degree != 0 {
+		t.Errorf("Expected call in-degree 0 after delete, got %d", degree)
+	}
+
+	repo.DeleteNode(ctx, "9876543210")
+	if degree := repo.GetDegree(ctx, "5555555555", models.EdgeTypeContact, DirectionIncoming); degree != 1 {
+		t.Errorf("Expected contact in-degree 1 after node delete, got %d", degree)
+	}
+
+	// Counters are rebuilt from the store on restore
+	var buf bytes.Buffer
+	repo.Snapshot(ctx, &buf)
+
+	restored := NewInMemoryGraphRepository()
+	if err := restored.Restore(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if degree := restored.GetDegree(ctx, "5555555555", models.EdgeTypeContact, DirectionBoth); degree != 2 {
+		t.Errorf("Expected contact degree 2 after restore, got %d", degree)
+	}
+}
+
+func TestCayleyGraphRepository_UpdateEdgeMetadata(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	timestamp := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	call, _ := repo.AddCallEdge("7379037972", "9876543210", true, 300, timestamp)
+
+	// Correct the call duration
+	updated, err := repo.UpdateEdgeMetadata(ctx, call.ID, &models.CallMetadata{
+		IsAnswered:        true,
+		DurationInSeconds: 5,
+		Timestamp:         timestamp,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if updated.ID != call.ID {
+		t.Errorf("Expected ID '%s' to be kept, got '%s'", call.ID, updated.ID)
+	}
+	if cm := updated.Metadata.(*models.CallMetadata); cm.DurationInSeconds != 5 {
+		t.Errorf("Expected duration 5, got %d", cm.DurationInSeconds)
+	}
+
+	// The call index sees the new duration
+	maxDuration := 10
+	if _, count := repo.GetCallsWithFilters(ctx, "7379037972", CallFilters{MaxDuration: &maxDuration}, DirectionOutgoing); count != 1 {
+		t.Errorf("Expected 1 short call after update, got %d", count)
+	}
+
+	// Rename a contact
+	contact, _ := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{
+		Name:    "John",
+		AddedAt: timestamp,
+	})
+
+	updated, err = repo.UpdateEdgeMetadata(ctx, contact.ID, &models.ContactMetadata{
+		Name:    "John Smith",
+		AddedAt: timestamp,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cm := updated.Metadata.(*models.ContactMetadata); cm.Name != "John Smith" {
+		t.Errorf("Expected name 'John Smith', got '%s'", cm.Name)
+	}
+	if len(repo.edgeQuadsUnsafe(ctx, contact.ID)) != 5 {
+		t.Errorf("Expected old name quad to be replaced, got %d quads", len(repo.edgeQuadsUnsafe(ctx, contact.ID)))
+	}
+
+	// Metadata must match the edge type
+	if _, err := repo.UpdateEdgeMetadata(ctx, contact.ID, &models.CallMetadata{}); !errors.Is(err, ErrInvalidEdgeType) {
+		t.Errorf("Expected ErrInvalidEdgeType, got %v", err)
+	}
+
+	// Metadata is validated
+	if _, err := repo.UpdateEdgeMetadata(ctx, call.ID, &models.CallMetadata{DurationInSeconds: -1}); err == nil {
+		t.Error("Expected error for invalid metadata")
+	}
+
+	if _, err := repo.UpdateEdgeMetadata(ctx, "missing", &models.CallMetadata{}); err != ErrEdgeNotFound {
+		t.Errorf("Expected ErrEdgeNotFound, got %v", err)
+	}
+}
+
+func TestCayleyGraphRepository_DeleteContactEdge(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	meta := &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()}
+	contact, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", meta)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", meta); err != ErrEdgeExists {
+		t.Errorf("Expected ErrEdgeExists for duplicate contact, got %v", err)
+	}
+
+	if err := repo.DeleteEdge(ctx, contact.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Every query sees the contact as gone
+	if repo.IsDirectContact(ctx, "7379037972", "9876543210") {
+		t.Error("Expected deleted contact not to be a direct contact")
+	}
+	if _, count := repo.GetUsersWithContact(ctx, "9876543210"); count != 0 {
+		t.Errorf("Expected 0 users with contact, got %d", count)
+	}
+	if _, err := repo.GetEdge(ctx, contact.ID); err != ErrEdgeNotFound {
+		t.Errorf("Expected ErrEdgeNotFound, got %v", err)
+	}
+
+	// The contact can be saved again under the same stable ID
+	readded, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", meta)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if readded.ID != contact.ID {
+		t.Errorf("Expected stable ID '%s', got '%s'", contact.ID, readded.ID)
+	}
+}
+
+func TestCayleyGraphRepository_FindEdge(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	report, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "14022000001", &models.SpamReportMetadata{
+		Category: models.SpamCategoryRobocall,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	found, err := repo.FindEdge(ctx, "7379037972", "14022000001", models.EdgeTypeSpamReport)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if found.ID != report.ID {
+		t.Errorf("Expected edge '%s', got '%s'", report.ID, found.ID)
+	}
+
+	// Direction and type both matter
+	if _, err := repo.FindEdge(ctx, "14022000001", "7379037972", models.EdgeTypeSpamReport); err != ErrEdgeNotFound {
+		t.Errorf("Expected ErrEdgeNotFound for reverse direction, got %v", err)
+	}
+	if _, err := repo.FindEdge(ctx, "7379037972", "14022000001", models.EdgeTypeContact); err != ErrEdgeNotFound {
+		t.Errorf("Expected ErrEdgeNotFound for other type, got %v", err)
+	}
+}
+
+// testVoicemailMetadata is a custom edge type registered at runtime
+type testVoicemailMetadata struct {
+	DurationInSeconds int
+	Transcribed       bool
+}
+
+func (m *testVoicemailMetadata) EdgeType() models.EdgeType { return "voicemail" }
+
+func (m *testVoicemailMetadata) ToProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"duration_in_seconds": m.DurationInSeconds,
+		"transcribed":         m.Transcribed,
+	}
+}
+
+func (m *testVoicemailMetadata) FromProperties(props map[string]interface{}) error {
+	if duration, ok := props["duration_in_seconds"].(int); ok {
+		m.DurationInSeconds = duration
+	} else if duration, ok := props["duration_in_seconds"].(float64); ok {
+		m.DurationInSeconds = int(duration)
+	}
+	if transcribed, ok := props["transcribed"].(bool); ok {
+		m.Transcribed = transcribed
+	}
+	return nil
+}
+
+func (m *testVoicemailMetadata) Validate() error { return nil }
+
+func TestCayleyGraphRepository_CustomEdgeType(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	// Unregistered types are rejected rather than silently dropped
+	if _, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &testVoicemailMetadata{}); !errors.Is(err, ErrInvalidEdgeType) {
+		t.Errorf("Expected ErrInvalidEdgeType, got %v", err)
+	}
+
+	if err := repo.RegisterEdgeType("voicemail", func() models.EdgeMetadata { return &testVoicemailMetadata{} }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, reserved := range []models.EdgeType{"from", NodePropertyTrustRank, spamLabelPredicate} {
+		if err := repo.RegisterEdgeType(reserved, func() models.EdgeMetadata { return &testVoicemailMetadata{} }); !errors.Is(err, ErrInvalidEdgeType) {
+			t.Errorf("Expected ErrInvalidEdgeType for reserved type %q, got %v", reserved, err)
+		}
+	}
+
+	edge, err := repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &testVoicemailMetadata{
+		DurationInSeconds: 42,
+		Transcribed:       true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Read back through the registry
+	got, err := repo.GetEdge(ctx, edge.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	vm, ok := got.Metadata.(*testVoicemailMetadata)
+	if !ok || vm.DurationInSeconds != 42 || !vm.Transcribed {
+		t.Errorf("Expected voicemail metadata to round-trip, got %+v", got.Metadata)
+	}
+
+	// Queryable in both directions
+	if edges := repo.GetOutgoingEdges(ctx, "7379037972", "voicemail"); len(edges) != 1 {
+		t.Errorf("Expected 1 outgoing voicemail edge, got %d", len(edges))
+	}
+	if edges := repo.GetIncomingEdges(ctx, "9876543210", "voicemail"); len(edges) != 1 {
+		t.Errorf("Expected 1 incoming voicemail edge, got %d", len(edges))
+	}
+
+	// Loadable from seed data
+	seedPath := filepath.Join(t.TempDir(), "seed.json")
+	os.WriteFile(seedPath, []byte(`{
+		"edges": [
+			{"id": "voicemail_1", "from": "5555555555", "to": "9876543210", "type": "voicemail",
+			 "properties": {"duration_in_seconds": 10, "transcribed": false}}
+		]
+	}`), 0644)
+
+	if err := repo.LoadSeedData(ctx, seedPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if edges := repo.GetIncomingEdges(ctx, "9876543210", "voicemail"); len(edges) != 2 {
+		t.Errorf("Expected 2 incoming voicemail edges after seed load, got %d", len(edges))
+	}
+
+	if err := repo.DeleteEdge(ctx, edge.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if edges := repo.GetOutgoingEdges(ctx, "7379037972", "voicemail"); len(edges) != 0 {
+		t.Errorf("Expected 0 outgoing voicemail edges after delete, got %d", len(edges))
+	}
+}
+
+func TestCayleyGraphRepository_GetReciprocatedNumbers(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	// 9876543210 called back, 5555555555 saved the caller, 1111111111 did neither
+	repo.AddCallEdge("9876543210", "7379037972", true, 30, time.Now())
+	repo.AddEdgeWithMetadata(ctx, "5555555555", "7379037972", &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()})
+	// The caller saving a number is not reciprocation
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "1111111111", &models.ContactMetadata{Name: "Bob", AddedAt: time.Now()})
+
+	reciprocated := repo.GetReciprocatedNumbers(ctx, "7379037972", []string{"9876543210", "5555555555", "1111111111"})
+
+	if len(reciprocated) != 2 || !reciprocated["9876543210"] || !reciprocated["5555555555"] {
+		t.Errorf("Expected 9876543210 and 5555555555 to reciprocate, got %v", reciprocated)
+	}
+
+	if reciprocated := repo.GetReciprocatedNumbers(ctx, "7379037972", nil); len(reciprocated) != 0 {
+		t.Errorf("Expected no results for no numbers, got %v", reciprocated)
+	}
+}
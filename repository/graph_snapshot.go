@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+// snapshotFormatVersion is the current version of the snapshot file format
+const snapshotFormatVersion = 1
+
+var ErrInvalidSnapshot = errors.New("invalid graph snapshot")
+
+// maxSnapshotBytes caps the decompressed size of a snapshot, so a small gzip bomb cannot exhaust memory
+var maxSnapshotBytes int64 = 2 << 30
+
+// GraphSnapshotter defines operations for exporting and importing the full graph
+type GraphSnapshotter interface {
+	// Snapshot writes every quad in the graph to w as a compressed, versioned snapshot
+	Snapshot(ctx context.Context, w io.Writer) error
+
+	// Restore replaces the graph contents with the snapshot read from r
+	Restore(ctx context.Context, r io.Reader) error
+}
+
+// snapshotValue is a typed quad value
+type snapshotValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// snapshotQuad is a single quad in a snapshot
+type snapshotQuad struct {
+	Subject   snapshotValue  `json:"subject"`
+	Predicate snapshotValue  `json:"predicate"`
+	Object    snapshotValue  `json:"object"`
+	Label     *snapshotValue `json:"label,omitempty"`
+}
+
+// graphSnapshot is the gzip-compressed JSON document written by Snapshot
+// Checksum is the hex SHA-256 of the JSON-encoded quads
+type graphSnapshot struct {
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	QuadCount int            `json:"quad_count"`
+	Checksum  string         `json:"checksum"`
+	Quads     []snapshotQuad `json:"quads"`
+}
+
+// Snapshot writes every node, edge and metadata quad to w
+func (r *CayleyGraphRepository) Snapshot(ctx context.Context, w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.writeSnapshotUnsafe(ctx, w)
+}
+
+// writeSnapshotUnsafe writes a snapshot of the store to w (must be called with lock held)
+func (r *CayleyGraphRepository) writeSnapshotUnsafe(ctx context.Context, w io.Writer) error {
+	quads := make([]snapshotQuad, 0)
+
+	it := r.store.QuadsAllIterator()
+	defer it.Close()
+
+	for it.Next(ctx) {
+		sq, err := encodeSnapshotQuad(r.store.Quad(it.Result()))
+		if err != nil {
+			return err
+		}
+		quads = append(quads, sq)
+	}
+
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to iterate quads: %w", err)
+	}
+
+	checksum, err := snapshotChecksum(quads)
+	if err != nil {
+		return err
+	}
+
+	snapshot := graphSnapshot{
+		Version:   snapshotFormatVersion,
+		CreatedAt: time.Now().UTC(),
+		QuadCount: len(quads),
+		Checksum:  checksum,
+		Quads:     quads,
+	}
+
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(&snapshot); err != nil {
+		gz.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return gz.Close()
+}
+
+// Restore replaces the graph contents with the snapshot read from r
+// The snapshot is fully validated before the store is modified, and the
+// change is applied as a single transaction
+func (r *CayleyGraphRepository) Restore(ctx context.Context, rd io.Reader) error {
+	quads, err := readSnapshot(rd)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.replaceQuadsUnsafe(ctx, quads); err != nil {
+		return err
+	}
+
+	// The mutation log no longer describes the store, so start a new one
+	if r.wal != nil {
+		return r.checkpointUnsafe(ctx)
+	}
+
+	return nil
+}
+
+// readSnapshot decodes and validates a snapshot
+func readSnapshot(rd io.Reader) ([]quad.Quad, error) {
+	gz, err := gzip.NewReader(rd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	defer gz.Close()
+
+	limited := &io.LimitedReader{R: gz, N: maxSnapshotBytes + 1}
+	var snapshot graphSnapshot
+	if err := json.NewDecoder(limited).Decode(&snapshot); err != nil {
+		if limited.N <= 0 {
+			return nil, fmt.Errorf("%w: larger than %d bytes decompressed", ErrInvalidSnapshot, maxSnapshotBytes)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	if snapshot.Version != snapshotFormatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, snapshot.Version)
+	}
+
+	if snapshot.QuadCount != len(snapshot.Quads) {
+		return nil, fmt.Errorf("%w: expected %d quads, found %d", ErrInvalidSnapshot, snapshot.QuadCount, len(snapshot.Quads))
+	}
+
+	checksum, err := snapshotChecksum(snapshot.Quads)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != snapshot.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+
+	quads := make([]quad.Quad, 0, len(snapshot.Quads))
+	for _, sq := range snapshot.Quads {
+		q, err := decodeSnapshotQuad(sq)
+		if err != nil {
+			return nil, err
+		}
+		quads = append(quads, q)
+	}
+
+	return quads, nil
+}
+
+// replaceQuadsUnsafe makes the store hold exactly the given quads in a single
+// transaction (must be called with lock held)
+func (r *CayleyGraphRepository) replaceQuadsUnsafe(ctx context.Context, quads []quad.Quad) error {
+	restored := make(map[quad.Quad]bool, len(quads))
+	for _, q := range quads {
+		restored[q] = true
+	}
+
+	// Only touch quads that differ between the store and the snapshot
+	tx := cayley.NewTransaction()
+
+	it := r.store.QuadsAllIterator()
+	defer it.Close()
+
+	for it.Next(ctx) {
+		q := r.store.Quad(it.Result())
+		if restored[q] {
+			delete(restored, q)
+			continue
+		}
+		tx.RemoveQuad(q)
+	}
+
+	if err := it.Err(); err != nil {
+		return fmt.Errorf("failed to iterate quads: %w", err)
+	}
+
+	for q := range restored {
+		tx.AddQuad(q)
+	}
+
+	if err := r.store.ApplyTransaction(tx); err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	return r.rebuildIndexesUnsafe(ctx)
+}
+
+// snapshotChecksum returns the hex SHA-256 of the JSON-encoded quads
+func snapshotChecksum(quads []snapshotQuad) (string, error) {
+	data, err := json.Marshal(quads)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode quads: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// encodeSnapshotQuad converts a quad to its snapshot form
+func encodeSnapshotQuad(q quad.Quad) (snapshotQuad, error) {
+	var sq snapshotQuad
+	var err error
+
+	if sq.Subject, err = encodeSnapshotValue(q.Subject); err != nil {
+		return sq, err
+	}
+	if sq.Predicate, err = encodeSnapshotValue(q.Predicate); err != nil {
+		return sq, err
+	}
+	if sq.Object, err = encodeSnapshotValue(q.Object); err != nil {
+		return sq, err
+	}
+	if q.Label != nil {
+		label, err := encodeSnapshotValue(q.Label)
+		if err != nil {
+			return sq, err
+		}
+		sq.Label = &label
+	}
+
+	return sq, nil
+}
+
+// decodeSnapshotQuad converts a snapshot quad back to a quad
+func decodeSnapshotQuad(sq snapshotQuad) (quad.Quad, error) {
+	var q quad.Quad
+	var err error
+
+	if q.Subject, err = decodeSnapshotValue(sq.Subject); err != nil {
+		return q, err
+	}
+	if q.Predicate, err = decodeSnapshotValue(sq.Predicate); err != nil {
+		return q, err
+	}
+	if q.Object, err = decodeSnapshotValue(sq.Object); err != nil {
+		return q, err
+	}
+	if sq.Label != nil {
+		if q.Label, err = decodeSnapshotValue(*sq.Label); err != nil {
+			return q, err
+		}
+	}
+
+	return q, nil
+}
+
+// encodeSnapshotValue converts a quad value to a typed snapshot value
+func encodeSnapshotValue(v quad.Value) (snapshotValue, error) {
+	switch val := v.(type) {
+	case quad.String:
+		return snapshotValue{Type: "string", Value: string(val)}, nil
+	case quad.IRI:
+		return snapshotValue{Type: "iri", Value: string(val)}, nil
+	case quad.Int:
+		return snapshotValue{Type: "int", Value: strconv.FormatInt(int64(val), 10)}, nil
+	case quad.Float:
+		return snapshotValue{Type: "float", Value: strconv.FormatFloat(float64(val), 'g', -1, 64)}, nil
+	case quad.Bool:
+		return snapshotValue{Type: "bool", Value: strconv.FormatBool(bool(val))}, nil
+	case quad.Time:
+		return snapshotValue{Type: "time", Value: time.Time(val).Format(time.RFC3339Nano)}, nil
+	default:
+		return snapshotValue{}, fmt.Errorf("unsupported quad value type %T", v)
+	}
+}
+
+// decodeSnapshotValue converts a typed snapshot value back to a quad value
+func decodeSnapshotValue(sv snapshotValue) (quad.Value, error) {
+	switch sv.Type {
+	case "string":
+		return quad.String(sv.Value), nil
+	case "iri":
+		return quad.IRI(sv.Value), nil
+	case "int":
+		i, err := strconv.ParseInt(sv.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid int value %q", ErrInvalidSnapshot, sv.Value)
+		}
+		return quad.Int(i), nil
+	case "float":
+		f, err := strconv.ParseFloat(sv.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid float value %q", ErrInvalidSnapshot, sv.Value)
+		}
+		return quad.Float(f), nil
+	case "bool":
+		b, err := strconv.ParseBool(sv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid bool value %q", ErrInvalidSnapshot, sv.Value)
+		}
+		return quad.Bool(b), nil
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, sv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid time value %q", ErrInvalidSnapshot, sv.Value)
+		}
+		return quad.Time(t), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown value type %q", ErrInvalidSnapshot, sv.Type)
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestCayleyGraphRepository_SnapshotRestore(t *testing.T) {
+	source := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	source.AddNodeWithName(ctx, "7379037972", "John")
+	source.AddNodeWithName(ctx, "9876543210", "Jane")
+	source.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()})
+	source.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.CallMetadata{
+		IsAnswered:        true,
+		DurationInSeconds: 45,
+		Timestamp:         time.Now().Add(-time.Hour),
+	})
+
+	var buf bytes.Buffer
+	if err := source.Snapshot(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error creating snapshot: %v", err)
+	}
+
+	// Restore into a repository holding unrelated data
+	target := NewInMemoryGraphRepository()
+	target.AddNode(ctx, "1111111111")
+
+	if err := target.Restore(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error restoring snapshot: %v", err)
+	}
+
+	if target.NodeExists(ctx, "1111111111") {
+		t.Error("Expected existing data to be replaced by the snapshot")
+	}
+
+	node, err := target.GetNode(ctx, "7379037972")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if node.Name != "John" {
+		t.Errorf("Expected name 'John', got '%s'", node.Name)
+	}
+
+	if !target.IsDirectContact(ctx, "7379037972", "9876543210") {
+		t.Error("Expected contact edge to be restored")
+	}
+
+	calls, count := target.GetCallsWithFilters(ctx, "7379037972", CallFilters{}, "outgoing")
+	if count != 1 {
+		t.Fatalf("Expected 1 restored call, got %d", count)
+	}
+	if cm, ok := calls[0].Metadata.(*models.CallMetadata); !ok || cm.DurationInSeconds != 45 {
+		t.Errorf("Expected restored call duration 45, got %+v", calls[0].Metadata)
+	}
+}
+
+func TestCayleyGraphRepository_Restore_Invalid(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+	repo.AddNode(ctx, "7379037972")
+
+	var buf bytes.Buffer
+	if err := repo.Snapshot(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error creating snapshot: %v", err)
+	}
+
+	// Decode and tamper with the snapshot
+	gz, _ := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	var snapshot graphSnapshot
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		t.Fatalf("Unexpected error decoding snapshot: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(s *graphSnapshot)
+	}{
+		{"checksum mismatch", func(s *graphSnapshot) { s.Quads[0].Subject.Value = "9999999999" }},
+		{"unsupported version", func(s *graphSnapshot) { s.Version = snapshotFormatVersion + 1 }},
+		{"quad count mismatch", func(s *graphSnapshot) { s.QuadCount++ }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := snapshot
+			tampered.Quads = append([]snapshotQuad(nil), snapshot.Quads...)
+			tt.mutate(&tampered)
+
+			var out bytes.Buffer
+			w := gzip.NewWriter(&out)
+			json.NewEncoder(w).Encode(&tampered)
+			w.Close()
+
+			err := repo.Restore(ctx, &out)
+			if !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+			}
+		})
+	}
+
+	// Not a gzip stream at all
+	if err := repo.Restore(ctx, bytes.NewBufferString("not a snapshot")); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+
+	// Failed restores must leave the store untouched
+	if !repo.NodeExists(ctx, "7379037972") {
+		t.Error("Expected node to survive failed restores")
+	}
+}
+
+func TestCayleyGraphRepository_Restore_TooLarge(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	defer func(limit int64) { maxSnapshotBytes = limit }(maxSnapshotBytes)
+	maxSnapshotBytes = 1024
+
+	// A few hundred bytes of gzip that decompress to 1MB
+	var out bytes.Buffer
+	w := gzip.NewWriter(&out)
+	w.Write([]byte(`{"version": 1, "quads": [`))
+	w.Write(bytes.Repeat([]byte(" "), 1<<20))
+	w.Close()
+
+	err := repo.Restore(ctx, &out)
+	if !errors.Is(err, ErrInvalidSnapshot) || !strings.Contains(err.Error(), "decompressed") {
+		t.Errorf("Expected ErrInvalidSnapshot for an oversized snapshot, got %v", err)
+	}
+}
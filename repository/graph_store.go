@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/cayley/graph"
+	_ "github.com/cayleygraph/cayley/graph/kv/bolt"
+	_ "github.com/cayleygraph/cayley/graph/kv/leveldb"
+)
+
+// Supported graph storage backends
+// Prefer leveldb for persistence: bolt (boltdb/bolt v1.3.1) fails checkptr and crashes in -race builds
+const (
+	GraphBackendMemory  = "memory"
+	GraphBackendBolt    = "bolt"
+	GraphBackendLevelDB = "leveldb"
+)
+
+var ErrUnsupportedBackend = errors.New("unsupported graph backend")
+
+// GraphStore defines lifecycle operations on the underlying graph storage
+type GraphStore interface {
+	// HasData reports whether the store already holds any quads
+	HasData(ctx context.Context) bool
+
+	// Close releases the underlying store
+	Close() error
+}
+
+// openGraphStore opens a Cayley handle for the given backend
+// Persistent backends are initialized on first use and reopened afterwards
+func openGraphStore(backend, dataPath string) (*cayley.Handle, error) {
+	switch backend {
+	case "", GraphBackendMemory:
+		store, err := cayley.NewMemoryGraph()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create memory graph: %w", err)
+		}
+		return store, nil
+	case GraphBackendBolt, GraphBackendLevelDB:
+		// handled below
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBackend, backend)
+	}
+
+	if dataPath == "" {
+		return nil, fmt.Errorf("data path is required for %s graph backend", backend)
+	}
+
+	if err := os.MkdirAll(dataPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create graph data directory: %w", err)
+	}
+
+	// Initialize the store; an existing database is reopened as-is
+	if err := graph.InitQuadStore(backend, dataPath, nil); err != nil && !errors.Is(err, graph.ErrDatabaseExists) {
+		return nil, fmt.Errorf("failed to initialize %s graph: %w", backend, err)
+	}
+
+	store, err := cayley.NewGraph(backend, dataPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s graph: %w", backend, err)
+	}
+
+	return store, nil
+}
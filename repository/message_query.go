@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"credCode/models"
+)
+
+// MessageFilters defines filters for querying message edges
+type MessageFilters struct {
+	SenderIDType   *string    // filter by numeric/alphanumeric sender ID
+	DeliveryStatus *string    // filter by sent/delivered/failed
+	MinLinkCount   *int       // minimum number of links
+	MinLength      *int       // minimum message length
+	MaxLength      *int       // maximum message length
+	TimeRangeStart *time.Time // start of time range
+	TimeRangeEnd   *time.Time // end of time range
+}
+
+// GetMessagesWithFilters returns message edges with applied filters
+// direction: "outgoing", "incoming", or "both"
+func (r *CayleyGraphRepository) GetMessagesWithFilters(ctx context.Context, phoneNumber string, filters MessageFilters, direction string) ([]*models.Edge, int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var candidateEdges []*models.Edge
+
+	// Gather candidate edges based on direction
+	switch direction {
+	case DirectionIncoming:
+		candidateEdges = r.getEdgesByPhoneUnsafe(ctx, phoneNumber, models.EdgeTypeMessage, "to")
+	case DirectionBoth:
+		candidateEdges = append(
+			r.getEdgesByPhoneUnsafe(ctx, phoneNumber, models.EdgeTypeMessage, "from"),
+			r.getEdgesByPhoneUnsafe(ctx, phoneNumber, models.EdgeTypeMessage, "to")...,
+		)
+	default:
+		candidateEdges = r.getEdgesByPhoneUnsafe(ctx, phoneNumber, models.EdgeTypeMessage, "from")
+	}
+
+	// Apply filters
+	filteredEdges := make([]*models.Edge, 0)
+	for _, edge := range candidateEdges {
+		if matchesMessageFilters(edge, filters) {
+			filteredEdges = append(filteredEdges, edge)
+		}
+	}
+
+	return filteredEdges, len(filteredEdges)
+}
+
+// matchesMessageFilters checks if an edge matches the given message filters
+func matchesMessageFilters(edge *models.Edge, filters MessageFilters) bool {
+	msgMeta, ok := edge.Metadata.(*models.MessageMetadata)
+	if !ok {
+		return false
+	}
+
+	if filters.SenderIDType != nil && msgMeta.SenderIDType != *filters.SenderIDType {
+		return false
+	}
+
+	if filters.DeliveryStatus != nil && msgMeta.DeliveryStatus != *filters.DeliveryStatus {
+		return false
+	}
+
+	if filters.MinLinkCount != nil && msgMeta.LinkCount < *filters.MinLinkCount {
+		return false
+	}
+
+	if filters.MinLength != nil && msgMeta.Length < *filters.MinLength {
+		return false
+	}
+
+	if filters.MaxLength != nil && msgMeta.Length > *filters.MaxLength {
+		return false
+	}
+
+	if filters.TimeRangeStart != nil && msgMeta.Timestamp.Before(*filters.TimeRangeStart) {
+		return false
+	}
+
+	if filters.TimeRangeEnd != nil && msgMeta.Timestamp.After(*filters.TimeRangeEnd) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestCayleyGraphRepository_GetMessagesWithFilters(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	now := time.Now()
+	messages := []*models.MessageMetadata{
+		{Timestamp: now, Length: 160, LinkCount: 2, SenderIDType: models.SenderIDAlphanumeric},
+		{Timestamp: now, Length: 150, LinkCount: 1, SenderIDType: models.SenderIDAlphanumeric},
+		{Timestamp: now, Length: 20, LinkCount: 0, DeliveryStatus: models.DeliveryStatusFailed},
+		{Timestamp: now.Add(-48 * time.Hour), Length: 140, LinkCount: 3},
+	}
+	for i, msg := range messages {
+		to := []string{"9876543210", "5555555555", "4444444444", "3333333333"}[i]
+		if _, err := repo.AddEdgeWithMetadata(ctx, "7379037972", to, msg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	_, count := repo.GetMessagesWithFilters(ctx, "7379037972", MessageFilters{}, DirectionOutgoing)
+	if count != 4 {
+		t.Errorf("Expected 4 outgoing messages, got %d", count)
+	}
+
+	// Bulk-sender style query: messages with links in the last day
+	minLinks := 1
+	dayAgo := now.Add(-24 * time.Hour)
+	_, count = repo.GetMessagesWithFilters(ctx, "7379037972", MessageFilters{
+		MinLinkCount:   &minLinks,
+		TimeRangeStart: &dayAgo,
+	}, DirectionOutgoing)
+	if count != 2 {
+		t.Errorf("Expected 2 recent messages with links, got %d", count)
+	}
+
+	alphanumeric := models.SenderIDAlphanumeric
+	_, count = repo.GetMessagesWithFilters(ctx, "7379037972", MessageFilters{SenderIDType: &alphanumeric}, DirectionOutgoing)
+	if count != 2 {
+		t.Errorf("Expected 2 alphanumeric messages, got %d", count)
+	}
+
+	failed := models.DeliveryStatusFailed
+	_, count = repo.GetMessagesWithFilters(ctx, "4444444444", MessageFilters{DeliveryStatus: &failed}, DirectionIncoming)
+	if count != 1 {
+		t.Errorf("Expected 1 failed incoming message, got %d", count)
+	}
+}
+
+func TestCayleyGraphRepository_LoadSeedData_Messages(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	seedPath := filepath.Join(t.TempDir(), "seed.json")
+	os.WriteFile(seedPath, []byte(`{
+		"edges": [
+			{"id": "message_1", "from": "VM-OFFERS", "to": "9876543210", "type": "message",
+			 "properties": {"length": 158, "link_count": 1, "sender_id_type": "alphanumeric", "delivery_status": "delivered"},
+			 "created_at": "2024-04-22T23:27:16Z"}
+		]
+	}`), 0644)
+
+	if err := repo.LoadSeedData(ctx, seedPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	edges, count := repo.GetMessagesWithFilters(ctx, "9876543210", MessageFilters{}, DirectionIncoming)
+	if count != 1 {
+		t.Fatalf("Expected 1 message, got %d", count)
+	}
+
+	msg := edges[0].Metadata.(*models.MessageMetadata)
+	if msg.Length != 158 || msg.SenderIDType != models.SenderIDAlphanumeric {
+		t.Errorf("Expected seeded message metadata, got %+v", msg)
+	}
+	if expected := time.Date(2024, 4, 22, 23, 27, 16, 0, time.UTC); !msg.Timestamp.Equal(expected) {
+		t.Errorf("Expected timestamp %v from created_at, got %v", expected, msg.Timestamp)
+	}
+}
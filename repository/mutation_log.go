@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"credCode/models"
+)
+
+// Mutation operations recorded in the mutation log
+const (
+	MutationAddNode           = "add_node"
+	MutationDeleteNode        = "delete_node"
+	MutationSetNodeProperties = "set_node_properties"
+	MutationAddEdge           = "add_edge"
+	MutationUpdateEdge        = "update_edge"
+	MutationDeleteEdge        = "delete_edge"
+	MutationLabelSpam         = "label_spam"
+	MutationUnlabelSpam       = "unlabel_spam"
+)
+
+var (
+	ErrCorruptMutationLog = errors.New("corrupt mutation log")
+	ErrMutationLogLocked  = errors.New("mutation log is in use by another process")
+)
+
+// MutationLogEntry is a single graph mutation recorded in the mutation log
+type MutationLogEntry struct {
+	Seq         uint64                 `json:"seq"`
+	Op          string                 `json:"op"`
+	Timestamp   time.Time              `json:"timestamp"`
+	PhoneNumber string                 `json:"phone_number,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	EdgeID      string                 `json:"edge_id,omitempty"`
+	From        string                 `json:"from,omitempty"`
+	To          string                 `json:"to,omitempty"`
+	EdgeType    models.EdgeType        `json:"edge_type,omitempty"`
+	Properties  map[string]interface{} `json:"properties,omitempty"`
+
+	// NodeProperties holds typed node property values (phone number -> key -> value),
+	// so that ints and floats keep their types on replay
+	NodeProperties map[string]map[string]snapshotValue `json:"node_properties,omitempty"`
+}
+
+// MutationLog is an append-only log of graph mutations
+// Each line holds the CRC-32 of an entry followed by the JSON-encoded entry,
+// and every append is fsync'd before it returns
+// A lock file next to the log is held exclusively while the log is open, so that only one
+// process appends to and rotates it
+type MutationLog struct {
+	path    string
+	file    *os.File
+	lock    *os.File
+	lastSeq uint64
+}
+
+// OpenMutationLog opens the mutation log at path, creating it if needed,
+// and returns the entries it already holds
+// A torn entry at the end of the log (from a crash mid-write) is truncated
+// Returns ErrMutationLogLocked if another process has the log open
+func OpenMutationLog(path string) (*MutationLog, []*MutationLogEntry, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create mutation log directory: %w", err)
+	}
+
+	// Lock a separate file, since rotation replaces the log file itself
+	lock, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open mutation log lock: %w", err)
+	}
+	if err := lockFile(lock); err != nil {
+		lock.Close()
+		return nil, nil, fmt.Errorf("%w: %s: %v", ErrMutationLogLocked, path, err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		lock.Close()
+		return nil, nil, fmt.Errorf("failed to open mutation log: %w", err)
+	}
+
+	entries, validSize, err := readMutationLog(file)
+	if err != nil {
+		file.Close()
+		lock.Close()
+		return nil, nil, err
+	}
+
+	// Drop any torn tail and position for appending
+	if err := file.Truncate(validSize); err != nil {
+		file.Close()
+		lock.Close()
+		return nil, nil, fmt.Errorf("failed to truncate mutation log: %w", err)
+	}
+	if _, err := file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		lock.Close()
+		return nil, nil, fmt.Errorf("failed to seek mutation log: %w", err)
+	}
+
+	log := &MutationLog{
+		path: path,
+		file: file,
+		lock: lock,
+	}
+	if len(entries) > 0 {
+		log.lastSeq = entries[len(entries)-1].Seq
+	}
+
+	return log, entries, nil
+}
+
+// readMutationLog reads all complete entries and returns the size of the valid prefix
+func readMutationLog(r io.Reader) ([]*MutationLogEntry, int64, error) {
+	entries := make([]*MutationLogEntry, 0)
+	reader := bufio.NewReader(r)
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A trailing line without a newline is a torn write
+			return entries, offset, nil
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read mutation log: %w", err)
+		}
+
+		entry, decodeErr := decodeMutationLogLine(line)
+		if decodeErr != nil {
+			// A bad final line is a torn write; anything earlier is corruption
+			if _, peekErr := reader.Peek(1); peekErr == io.EOF {
+				return entries, offset, nil
+			}
+			return nil, 0, fmt.Errorf("%w at offset %d: %v", ErrCorruptMutationLog, offset, decodeErr)
+		}
+
+		entries = append(entries, entry)
+		offset += int64(len(line))
+	}
+}
+
+// decodeMutationLogLine verifies and decodes a single log line
+func decodeMutationLogLine(line []byte) (*MutationLogEntry, error) {
+	checksum, data, found := bytes.Cut(bytes.TrimSuffix(line, []byte("\n")), []byte(" "))
+	if !found {
+		return nil, errors.New("missing checksum")
+	}
+
+	expected, err := strconv.ParseUint(string(checksum), 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum: %w", err)
+	}
+	if crc32.ChecksumIEEE(data) != uint32(expected) {
+		return nil, errors.New("checksum mismatch")
+	}
+
+	var entry MutationLogEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Append durably records an entry, assigning its sequence number and timestamp
+func (l *MutationLog) Append(entry *MutationLogEntry) error {
+	return l.AppendBatch([]*MutationLogEntry{entry})
+}
+
+// AppendBatch durably records entries in order with a single fsync, assigning their sequence numbers and timestamps
+// After a crash mid-batch, replay sees a prefix of the batch
+func (l *MutationLog) AppendBatch(entries []*MutationLogEntry) error {
+	var buf bytes.Buffer
+	seq := l.lastSeq
+	for _, entry := range entries {
+		seq++
+		entry.Seq = seq
+		if entry.Timestamp.IsZero() {
+			entry.Timestamp = time.Now().UTC()
+		}
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode mutation: %w", err)
+		}
+
+		fmt.Fprintf(&buf, "%08x %s\n", crc32.ChecksumIEEE(data), data)
+	}
+
+	if _, err := l.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write mutation: %w", err)
+	}
+
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync mutation log: %w", err)
+	}
+
+	l.lastSeq = seq
+	return nil
+}
+
+// Rotate archives the current log next to it and starts an empty one
+// Archived logs are kept as an audit history of mutations
+func (l *MutationLog) Rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close mutation log: %w", err)
+	}
+
+	archivePath := fmt.Sprintf("%s.%s", l.path, time.Now().UTC().Format("20060102T150405.000000000Z"))
+	if err := os.Rename(l.path, archivePath); err != nil {
+		return fmt.Errorf("failed to archive mutation log: %w", err)
+	}
+
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open mutation log: %w", err)
+	}
+
+	if err := syncDir(filepath.Dir(l.path)); err != nil {
+		file.Close()
+		return err
+	}
+
+	l.file = file
+	l.lastSeq = 0
+	return nil
+}
+
+// Close closes the log file and releases its lock
+func (l *MutationLog) Close() error {
+	err := l.file.Close()
+	if lockErr := l.lock.Close(); err == nil {
+		err = lockErr
+	}
+	return err
+}
+
+// syncDir fsyncs a directory so that renames and creates within it are durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package repository
+
+import "os"
+
+// lockFile is a no-op where flock is unavailable; only one process may open a mutation log
+func lockFile(file *os.File) error {
+	return nil
+}
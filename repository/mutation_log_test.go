@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMutationLog_AppendAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.wal")
+
+	log, entries, err := OpenMutationLog(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("Expected empty log, got %d entries", len(entries))
+	}
+
+	log.Append(&MutationLogEntry{Op: MutationAddNode, PhoneNumber: "7379037972", Name: "John"})
+	log.Append(&MutationLogEntry{Op: MutationDeleteNode, PhoneNumber: "7379037972"})
+	log.Close()
+
+	log, entries, err = OpenMutationLog(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer log.Close()
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Op != MutationAddNode || entries[0].Name != "John" || entries[0].Seq != 1 {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+
+	// Sequence numbers continue after reopening
+	entry := &MutationLogEntry{Op: MutationAddNode, PhoneNumber: "9876543210"}
+	log.Append(entry)
+	if entry.Seq != 3 {
+		t.Errorf("Expected seq 3, got %d", entry.Seq)
+	}
+}
+
+func TestMutationLog_TornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.wal")
+
+	log, _, _ := OpenMutationLog(path)
+	log.Append(&MutationLogEntry{Op: MutationAddNode, PhoneNumber: "7379037972"})
+	log.Close()
+
+	// Simulate a crash in the middle of writing the next entry
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString(`1234abcd {"seq":2,"op":"add_no`)
+	file.Close()
+
+	log, entries, err := OpenMutationLog(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer log.Close()
+
+	if len(entries) != 1 {
+		t.Errorf("Expected torn entry to be dropped, got %d entries", len(entries))
+	}
+}
+
+func TestMutationLog_CorruptEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.wal")
+
+	log, _, _ := OpenMutationLog(path)
+	log.Append(&MutationLogEntry{Op: MutationAddNode, PhoneNumber: "7379037972"})
+	log.Append(&MutationLogEntry{Op: MutationAddNode, PhoneNumber: "9876543210"})
+	log.Close()
+
+	// Flip a byte in the first entry
+	data, _ := os.ReadFile(path)
+	data[20] ^= 0xff
+	os.WriteFile(path, data, 0644)
+
+	_, _, err := OpenMutationLog(path)
+	if !errors.Is(err, ErrCorruptMutationLog) {
+		t.Errorf("Expected ErrCorruptMutationLog, got %v", err)
+	}
+}
+
+func TestMutationLog_Locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.wal")
+
+	log, _, err := OpenMutationLog(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A second writer (e.g. graphctl next to a running server) must not share the log
+	if _, _, err := OpenMutationLog(path); !errors.Is(err, ErrMutationLogLocked) {
+		t.Fatalf("Expected ErrMutationLogLocked, got %v", err)
+	}
+
+	// The lock survives rotation and is released on close
+	if err := log.Rotate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, err := OpenMutationLog(path); !errors.Is(err, ErrMutationLogLocked) {
+		t.Errorf("Expected ErrMutationLogLocked after rotation, got %v", err)
+	}
+	log.Close()
+
+	log, _, err = OpenMutationLog(path)
+	if err != nil {
+		t.Fatalf("Expected the log to open after close, got %v", err)
+	}
+	log.Close()
+}
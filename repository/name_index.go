@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+// NameIndexer is implemented by repositories that keep a name index up to date
+type NameIndexer interface {
+	// SetNameIndex indexes the names the repository already holds and keeps the index updated on writes
+	SetNameIndex(index *NameIndex)
+}
+
+// nameRecording is one spelling of a name recorded from a source
+type nameRecording struct {
+	spelling string
+	source   string
+}
+
+// nameEntry is one normalised name recorded for a phone number
+type nameEntry struct {
+	tokens     []string
+	recordings map[nameRecording]int
+	count      int
+}
+
+// NameIndex is an in-memory inverted index from name tokens to phone numbers
+// Names are normalised (models.NormaliseName) and split into tokens; a sorted token
+// vocabulary serves prefix matches and a trigram index finds fuzzy (edit distance) matches
+type NameIndex struct {
+	entries    map[string]map[string]*nameEntry // phone number -> normalised name -> entry
+	postings   map[string]map[string]int        // token -> phone number -> entries containing the token
+	vocabulary *prefixIndex                     // indexed tokens in sorted order
+	trigrams   map[string]map[string]bool       // trigram -> tokens containing it
+	mu         sync.RWMutex
+}
+
+// NewNameIndex creates an empty name index
+func NewNameIndex() *NameIndex {
+	return &NameIndex{
+		entries:    make(map[string]map[string]*nameEntry),
+		postings:   make(map[string]map[string]int),
+		vocabulary: newPrefixIndex(),
+		trigrams:   make(map[string]map[string]bool),
+	}
+}
+
+// Add records a name for a phone number
+func (ix *NameIndex) Add(phoneNumber, name, source string) {
+	key := models.NormaliseName(name)
+	if phoneNumber == "" || key == "" {
+		return
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	names, ok := ix.entries[phoneNumber]
+	if !ok {
+		names = make(map[string]*nameEntry)
+		ix.entries[phoneNumber] = names
+	}
+
+	entry, ok := names[key]
+	if !ok {
+		entry = &nameEntry{
+			tokens:     uniqueTokens(key),
+			recordings: make(map[nameRecording]int),
+		}
+		names[key] = entry
+
+		for _, token := range entry.tokens {
+			ix.addPostingUnsafe(token, phoneNumber)
+		}
+	}
+
+	entry.recordings[nameRecording{spelling: strings.Join(strings.Fields(name), " "), source: source}]++
+	entry.count++
+}
+
+// Remove forgets one recording of a name for a phone number
+func (ix *NameIndex) Remove(phoneNumber, name, source string) {
+	key := models.NormaliseName(name)
+	if key == "" {
+		return
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.removeUnsafe(phoneNumber, key, nameRecording{spelling: strings.Join(strings.Fields(name), " "), source: source})
+}
+
+// RemoveSource forgets every name recorded from a source
+func (ix *NameIndex) RemoveSource(source string) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	for phoneNumber, names := range ix.entries {
+		for key, entry := range names {
+			for recording, n := range entry.recordings {
+				if recording.source != source {
+					continue
+				}
+				for i := 0; i < n; i++ {
+					ix.removeUnsafe(phoneNumber, key, recording)
+				}
+			}
+		}
+	}
+}
+
+// removeUnsafe decrements a name entry, dropping it and its postings at zero (must be called with lock held)
+func (ix *NameIndex) removeUnsafe(phoneNumber, key string, recording nameRecording) {
+	entry, ok := ix.entries[phoneNumber][key]
+	if !ok || entry.recordings[recording] == 0 {
+		return
+	}
+
+	entry.count--
+	if entry.recordings[recording]--; entry.recordings[recording] == 0 {
+		delete(entry.recordings, recording)
+	}
+
+	if entry.count > 0 {
+		return
+	}
+
+	delete(ix.entries[phoneNumber], key)
+	if len(ix.entries[phoneNumber]) == 0 {
+		delete(ix.entries, phoneNumber)
+	}
+
+	for _, token := range entry.tokens {
+		ix.removePostingUnsafe(token, phoneNumber)
+	}
+}
+
+// addPostingUnsafe links a token to a phone number, indexing new tokens (must be called with lock held)
+func (ix *NameIndex) addPostingUnsafe(token, phoneNumber string) {
+	phones, ok := ix.postings[token]
+	if !ok {
+		phones = make(map[string]int)
+		ix.postings[token] = phones
+
+		ix.vocabulary.add(token)
+		for _, trigram := range trigramsOf(token) {
+			if ix.trigrams[trigram] == nil {
+				ix.trigrams[trigram] = make(map[string]bool)
+			}
+			ix.trigrams[trigram][token] = true
+		}
+	}
+	phones[phoneNumber]++
+}
+
+// removePostingUnsafe unlinks a token from a phone number, dropping unused tokens (must be called with lock held)
+func (ix *NameIndex) removePostingUnsafe(token, phoneNumber string) {
+	phones := ix.postings[token]
+	if phones[phoneNumber]--; phones[phoneNumber] > 0 {
+		return
+	}
+	delete(phones, phoneNumber)
+
+	if len(phones) > 0 {
+		return
+	}
+	delete(ix.postings, token)
+
+	ix.vocabulary.remove(token)
+	for _, trigram := range trigramsOf(token) {
+		delete(ix.trigrams[trigram], token)
+		if len(ix.trigrams[trigram]) == 0 {
+			delete(ix.trigrams, trigram)
+		}
+	}
+}
+
+// Search returns the phone numbers whose names match query, best match first, and the total match count
+// Every query word is matched against name words exactly, as a prefix or within a small edit distance;
+// a name's score is the average of its best match per query word, so word order does not matter
+// offset and limit select the page of results
+func (ix *NameIndex) Search(query string, offset, limit int) ([]models.NameSearchResult, int) {
+	queryTokens := strings.Fields(models.NormaliseName(query))
+	if len(queryTokens) == 0 {
+		return []models.NameSearchResult{}, 0
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	// Score every indexed token against each query word
+	matches := make([]map[string]float64, len(queryTokens))
+	candidates := make(map[string]bool)
+	for i, queryToken := range queryTokens {
+		matches[i] = ix.matchTokenUnsafe(queryToken)
+		for token := range matches[i] {
+			for phoneNumber := range ix.postings[token] {
+				candidates[phoneNumber] = true
+			}
+		}
+	}
+
+	results := make([]models.NameSearchResult, 0, len(candidates))
+	for phoneNumber := range candidates {
+		var best *nameEntry
+		var bestScore float64
+
+		for _, entry := range ix.entries[phoneNumber] {
+			score := entry.score(matches)
+			if score > bestScore || (score == bestScore && best != nil && entry.count > best.count) {
+				best, bestScore = entry, score
+			}
+		}
+
+		if best == nil {
+			continue
+		}
+
+		results = append(results, models.NameSearchResult{
+			PhoneNumber: phoneNumber,
+			Name:        best.displayName(),
+			Score:       bestScore,
+			Count:       best.count,
+			Sources:     best.sourceNames(),
+		})
+	}
+
+	// Best match first; more frequently recorded names break ties
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].PhoneNumber < results[j].PhoneNumber
+	})
+
+	total := len(results)
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && limit < total-offset {
+		end = offset + limit
+	}
+
+	return results[offset:end], total
+}
+
+// matchTokenUnsafe returns the indexed tokens matching a query word and their match scores (must be called with lock held)
+// Exact: 1.0; prefix: 0.6 to 0.9 by how much of the token the word covers; fuzzy: up to 0.8 by edit similarity
+func (ix *NameIndex) matchTokenUnsafe(queryToken string) map[string]float64 {
+	matches := make(map[string]float64)
+	queryLength := utf8.RuneCountInString(queryToken)
+
+	if _, ok := ix.postings[queryToken]; ok {
+		matches[queryToken] = 1.0
+	}
+
+	for _, token := range ix.vocabulary.withPrefix(queryToken) {
+		if token != queryToken {
+			matches[token] = 0.6 + 0.3*float64(queryLength)/float64(utf8.RuneCountInString(token))
+		}
+	}
+
+	// Fuzzy: tokens sharing a trigram, within 1 edit (2 for words longer than 5 letters)
+	maxEdits := 1
+	if queryLength > 5 {
+		maxEdits = 2
+	}
+	if queryLength < 3 {
+		return matches
+	}
+
+	checked := make(map[string]bool)
+	for _, trigram := range trigramsOf(queryToken) {
+		for token := range ix.trigrams[trigram] {
+			if checked[token] {
+				continue
+			}
+			checked[token] = true
+
+			distance := models.EditDistance(queryToken, token)
+			if distance == 0 || distance > maxEdits {
+				continue
+			}
+
+			score := 0.8 * models.NameSimilarity(queryToken, token)
+			if score > matches[token] {
+				matches[token] = score
+			}
+		}
+	}
+
+	return matches
+}
+
+// score returns how well a name matches the query: the average over query words of the best matching name word
+func (e *nameEntry) score(matches []map[string]float64) float64 {
+	var total float64
+	for _, wordMatches := range matches {
+		var best float64
+		for _, token := range e.tokens {
+			if score := wordMatches[token]; score > best {
+				best = score
+			}
+		}
+		total += best
+	}
+	return total / float64(len(matches))
+}
+
+// displayName returns the most common recorded spelling of the name
+func (e *nameEntry) displayName() string {
+	spellings := make(map[string]int)
+	for recording, n := range e.recordings {
+		spellings[recording.spelling] += n
+	}
+
+	var name string
+	count := 0
+	for spelling, n := range spellings {
+		if n > count || (n == count && spelling < name) {
+			name, count = spelling, n
+		}
+	}
+	return name
+}
+
+// sourceNames returns the sources the name was recorded from, sorted
+func (e *nameEntry) sourceNames() []string {
+	seen := make(map[string]bool)
+	sources := make([]string, 0)
+	for recording := range e.recordings {
+		if !seen[recording.source] {
+			seen[recording.source] = true
+			sources = append(sources, recording.source)
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// uniqueTokens splits a normalised name into its distinct words
+func uniqueTokens(key string) []string {
+	seen := make(map[string]bool)
+	tokens := make([]string, 0)
+	for _, token := range strings.Fields(key) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// trigramsOf returns the trigrams of a token padded with '$', over runes
+func trigramsOf(token string) []string {
+	runes := []rune("$" + token + "$")
+	trigrams := make([]string, 0, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		trigrams = append(trigrams, string(runes[i:i+3]))
+	}
+	return trigrams
+}
+
+// SetNameIndex indexes node names and keeps the index updated as named nodes are added and deleted
+// Contact edge names are not indexed: the graph builder mirrors them from the user repository,
+// which indexes contact names itself, so names written only to graph contact edges are not searchable
+func (r *CayleyGraphRepository) SetNameIndex(index *NameIndex) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nameIndex = index
+	r.rebuildNameIndexUnsafe(context.Background())
+}
+
+// rebuildNameIndexUnsafe re-indexes all node names, if a name index is attached (must be called with lock held)
+func (r *CayleyGraphRepository) rebuildNameIndexUnsafe(ctx context.Context) {
+	if r.nameIndex == nil {
+		return
+	}
+
+	r.nameIndex.RemoveSource(models.NameSourceNode)
+
+	for _, phoneNumber := range r.numbers.withPrefix("") {
+		p := cayley.StartPath(r.store, quad.String(phoneNumber)).Out(quad.String("name"))
+		for _, name := range r.collectStringsUnsafe(ctx, p) {
+			r.nameIndex.Add(phoneNumber, name, models.NameSourceNode)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestNameIndex_Search(t *testing.T) {
+	ix := NewNameIndex()
+
+	ix.Add("7379037972", "John Smith", models.NameSourceUser)
+	ix.Add("7379037972", "Johnny", models.NameSourceContact)
+	ix.Add("9876543210", "Jonathan Smith", models.NameSourceUser)
+	ix.Add("5555555555", "Jon Smyth", models.NameSourceContact)
+	ix.Add("5555555555", "Jon Smyth", models.NameSourceContact)
+	ix.Add("1234567890", "Pizza Hut", models.NameSourceNode)
+
+	tests := []struct {
+		query    string
+		expected string // best match
+		total    int
+	}{
+		{"john smith", "7379037972", 3}, // exact; prefix "jon" of jonathan and fuzzy "jon smyth" follow
+		{"Smith John", "7379037972", 3}, // word order is ignored
+		{"jonat", "9876543210", 1},      // prefix
+		{"smyth", "5555555555", 3},      // exact for one, fuzzy for the smiths
+		{"piza", "1234567890", 1},       // fuzzy
+		{"Mr. Pizza", "1234567890", 1},  // honorifics are ignored
+	}
+
+	for _, tt := range tests {
+		results, total := ix.Search(tt.query, 0, 10)
+		if total != tt.total || len(results) == 0 || results[0].PhoneNumber != tt.expected {
+			t.Errorf("Search(%q): expected %s first of %d, got %d results %+v", tt.query, tt.expected, tt.total, total, results)
+		}
+	}
+
+	if results, total := ix.Search("zzz", 0, 10); total != 0 || len(results) != 0 {
+		t.Errorf("Expected no results, got %+v", results)
+	}
+
+	results, _ := ix.Search("jon smyth", 0, 10)
+	if results[0].Score != 1.0 || results[0].Count != 2 || results[0].Name != "Jon Smyth" || results[0].Sources[0] != models.NameSourceContact {
+		t.Errorf("Unexpected exact match %+v", results[0])
+	}
+}
+
+func TestNameIndex_Pagination(t *testing.T) {
+	ix := NewNameIndex()
+
+	ix.Add("1000000001", "Anna", models.NameSourceUser)
+	ix.Add("1000000002", "Anna", models.NameSourceUser)
+	ix.Add("1000000002", "Anna", models.NameSourceContact)
+	ix.Add("1000000003", "Annabel", models.NameSourceUser)
+
+	page, total := ix.Search("anna", 0, 2)
+	if total != 3 || len(page) != 2 {
+		t.Fatalf("Expected first page of 2 from 3, got %d of %d", len(page), total)
+	}
+
+	// Equal scores: the more often recorded name ranks first
+	if page[0].PhoneNumber != "1000000002" || page[1].PhoneNumber != "1000000001" {
+		t.Errorf("Unexpected order %+v", page)
+	}
+
+	page, _ = ix.Search("anna", 2, 2)
+	if len(page) != 1 || page[0].PhoneNumber != "1000000003" {
+		t.Errorf("Expected last page [1000000003], got %+v", page)
+	}
+
+	if page, _ := ix.Search("anna", 10, 2); len(page) != 0 {
+		t.Errorf("Expected empty page past the end, got %+v", page)
+	}
+
+	if page, _ := ix.Search("anna", -4, 2); len(page) != 2 || page[0].PhoneNumber != "1000000002" {
+		t.Errorf("Expected a negative offset to return the first page, got %+v", page)
+	}
+}
+
+func TestNameIndex_Remove(t *testing.T) {
+	ix := NewNameIndex()
+
+	ix.Add("7379037972", "John", models.NameSourceContact)
+	ix.Add("7379037972", "John", models.NameSourceContact)
+	ix.Add("7379037972", "John", models.NameSourceNode)
+
+	ix.Remove("7379037972", "John", models.NameSourceContact)
+	if results, _ := ix.Search("john", 0, 10); len(results) != 1 || results[0].Count != 2 {
+		t.Errorf("Expected one remaining contact recording, got %+v", results)
+	}
+
+	ix.RemoveSource(models.NameSourceContact)
+	ix.RemoveSource(models.NameSourceNode)
+	if results, total := ix.Search("john", 0, 10); total != 0 {
+		t.Errorf("Expected no results after removing all recordings, got %+v", results)
+	}
+
+	// Tokens of removed names no longer match
+	if len(ix.postings) != 0 || len(ix.trigrams) != 0 || len(ix.vocabulary.withPrefix("")) != 0 {
+		t.Error("Expected the index to be empty")
+	}
+}
+
+func TestNameIndex_UpdatedByRepositories(t *testing.T) {
+	ctx := context.Background()
+	ix := NewNameIndex()
+
+	graphRepo := NewInMemoryGraphRepository()
+	graphRepo.AddNodeWithName(ctx, "1234567890", "Pizza Hut")
+	graphRepo.SetNameIndex(ix)
+	graphRepo.AddNodeWithName(ctx, "5555555555", "Pizza Express")
+
+	userRepo := NewInMemoryUserRepository()
+	userRepo.SetNameIndex(ix)
+	userRepo.CreateUser(ctx, &models.User{ID: "user1", PhoneNumber: "7379037972", Name: "John"})
+	userRepo.AddContact(ctx, "user1", &models.Contact{ID: "c1", PhoneNumber: "9876543210", Name: "Pizza Place", AddedAt: time.Now()})
+
+	if _, total := ix.Search("pizza", 0, 10); total != 3 {
+		t.Errorf("Expected 3 pizza numbers, got %d", total)
+	}
+	if results, _ := ix.Search("john", 0, 10); len(results) != 1 || results[0].Sources[0] != models.NameSourceUser {
+		t.Errorf("Expected user name 'John' to be indexed, got %+v", results)
+	}
+
+	graphRepo.DeleteNode(ctx, "5555555555")
+	userRepo.DeleteContact(ctx, "user1", "c1")
+	if results, total := ix.Search("pizza", 0, 10); total != 1 || results[0].PhoneNumber != "1234567890" {
+		t.Errorf("Expected only 1234567890 after deletes, got %+v", results)
+	}
+}
+
+func TestNameIndex_GraphContactEdgesNotIndexed(t *testing.T) {
+	ctx := context.Background()
+	ix := NewNameIndex()
+
+	graphRepo := NewInMemoryGraphRepository()
+	graphRepo.SetNameIndex(ix)
+
+	// Contact names come from the user repository; names only on graph contact edges are not indexed
+	edge, _ := graphRepo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()})
+	graphRepo.UpdateEdgeMetadata(ctx, edge.ID, &models.ContactMetadata{Name: "Janet", AddedAt: time.Now()})
+
+	if _, total := ix.Search("jane", 0, 10); total != 0 {
+		t.Errorf("Expected graph contact edge names not to be indexed, got %d matches", total)
+	}
+}
+
+func TestNameIndex_SeedData(t *testing.T) {
+	ctx := context.Background()
+	ix := NewNameIndex()
+
+	graphRepo := NewInMemoryGraphRepository()
+	graphRepo.SetNameIndex(ix)
+
+	seedPath := filepath.Join(t.TempDir(), "seed.json")
+	os.WriteFile(seedPath, []byte(`{"nodes": [{"phone_number": "1234567890", "name": "Pizza Hut"}], "edges": []}`), 0644)
+	if err := graphRepo.LoadSeedData(ctx, seedPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if results, total := ix.Search("pizza", 0, 10); total != 1 || results[0].PhoneNumber != "1234567890" {
+		t.Errorf("Expected the seeded node name to be indexed, got %+v", results)
+	}
+}
@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/quad"
+)
+
+var ErrInvalidNodeProperty = errors.New("invalid node property")
+
+// Node property keys written by background jobs
+const (
+	NodePropertyTrustRank = "trust_rank"
+)
+
+// SetNodeProperties sets properties on an existing node, replacing any previous values of the same keys
+// Stored as quads: phoneNumber -> <key> -> value
+func (r *CayleyGraphRepository) SetNodeProperties(ctx context.Context, phoneNumber string, properties map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	typed, err := r.encodeNodePropertiesUnsafe(properties)
+	if err != nil {
+		return err
+	}
+
+	if !r.nodeExistsUnsafe(phoneNumber) {
+		return ErrNodeNotFound
+	}
+
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:             MutationSetNodeProperties,
+		NodeProperties: map[string]map[string]snapshotValue{phoneNumber: typed},
+	}); err != nil {
+		return err
+	}
+
+	return r.applySetNodePropertiesUnsafe(ctx, phoneNumber, properties)
+}
+
+// SetNodePropertiesBatch sets properties on many nodes (phone number -> properties) in one
+// transaction and one mutation log entry, e.g. the scores of a background job
+// Nodes that no longer exist are skipped
+func (r *CayleyGraphRepository) SetNodePropertiesBatch(ctx context.Context, properties map[string]map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := make(map[string]map[string]interface{}, len(properties))
+	typed := make(map[string]map[string]snapshotValue, len(properties))
+	for phoneNumber, nodeProperties := range properties {
+		typedProperties, err := r.encodeNodePropertiesUnsafe(nodeProperties)
+		if err != nil {
+			return err
+		}
+
+		if !r.nodeExistsUnsafe(phoneNumber) {
+			continue
+		}
+
+		batch[phoneNumber] = nodeProperties
+		typed[phoneNumber] = typedProperties
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:             MutationSetNodeProperties,
+		NodeProperties: typed,
+	}); err != nil {
+		return err
+	}
+
+	return r.applySetNodePropertiesBatchUnsafe(ctx, batch)
+}
+
+// encodeNodePropertiesUnsafe validates properties and converts their values to typed log values (must be called with lock held)
+func (r *CayleyGraphRepository) encodeNodePropertiesUnsafe(properties map[string]interface{}) (map[string]snapshotValue, error) {
+	typed := make(map[string]snapshotValue, len(properties))
+	for key, value := range properties {
+		if err := r.validateNodePropertyUnsafe(key); err != nil {
+			return nil, err
+		}
+
+		qv, ok := quad.AsValue(value)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported value type %T for %q", ErrInvalidNodeProperty, value, key)
+		}
+
+		sv, err := encodeSnapshotValue(qv)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidNodeProperty, err)
+		}
+		typed[key] = sv
+	}
+
+	return typed, nil
+}
+
+// decodeNodeProperties converts typed log values back to property values
+func decodeNodeProperties(typed map[string]snapshotValue) (map[string]interface{}, error) {
+	properties := make(map[string]interface{}, len(typed))
+	for key, sv := range typed {
+		value, err := decodeSnapshotValue(sv)
+		if err != nil {
+			return nil, err
+		}
+		properties[key] = value
+	}
+
+	return properties, nil
+}
+
+// validateNodePropertyUnsafe rejects keys that would collide with node structure or edge adjacency quads
+func (r *CayleyGraphRepository) validateNodePropertyUnsafe(key string) error {
+	switch key {
+	case "", "type", "name", "from", "to", spamLabelPredicate:
+		return fmt.Errorf("%w: %q is reserved", ErrInvalidNodeProperty, key)
+	}
+
+	if r.registry.IsRegistered(models.EdgeType(key)) {
+		return fmt.Errorf("%w: %q is an edge type", ErrInvalidNodeProperty, key)
+	}
+
+	return nil
+}
+
+// applySetNodePropertiesUnsafe replaces the property quads of a node in one transaction (must be called with lock held)
+func (r *CayleyGraphRepository) applySetNodePropertiesUnsafe(ctx context.Context, phoneNumber string, properties map[string]interface{}) error {
+	if !r.nodeExistsUnsafe(phoneNumber) {
+		return ErrNodeNotFound
+	}
+
+	tx := cayley.NewTransaction()
+	r.replaceNodePropertiesUnsafe(ctx, tx, phoneNumber, properties)
+
+	if err := r.store.ApplyTransaction(tx); err != nil {
+		return fmt.Errorf("failed to set node properties: %w", err)
+	}
+
+	return nil
+}
+
+// applySetNodePropertiesBatchUnsafe replaces the property quads of many nodes in one transaction,
+// skipping nodes that do not exist (must be called with lock held)
+func (r *CayleyGraphRepository) applySetNodePropertiesBatchUnsafe(ctx context.Context, properties map[string]map[string]interface{}) error {
+	tx := cayley.NewTransaction()
+	for phoneNumber, nodeProperties := range properties {
+		if r.nodeExistsUnsafe(phoneNumber) {
+			r.replaceNodePropertiesUnsafe(ctx, tx, phoneNumber, nodeProperties)
+		}
+	}
+
+	if err := r.store.ApplyTransaction(tx); err != nil {
+		return fmt.Errorf("failed to set node properties: %w", err)
+	}
+
+	return nil
+}
+
+// replaceNodePropertiesUnsafe adds the quads replacing the given properties of a node to tx (must be called with lock held)
+func (r *CayleyGraphRepository) replaceNodePropertiesUnsafe(ctx context.Context, tx *graph.Transaction, phoneNumber string, properties map[string]interface{}) {
+	for key, value := range properties {
+		p := cayley.StartPath(r.store, quad.String(phoneNumber)).Out(quad.String(key))
+		it, _ := p.BuildIterator().Optimize()
+		for it.Next(ctx) {
+			tx.RemoveQuad(quad.Quad{
+				Subject:   quad.String(phoneNumber),
+				Predicate: quad.String(key),
+				Object:    r.store.NameOf(it.Result()),
+			})
+		}
+		it.Close()
+
+		tx.AddQuad(quad.Make(phoneNumber, key, value, nil))
+	}
+}
+
+// GetNodeProperty returns a property of a node, and whether it is set
+func (r *CayleyGraphRepository) GetNodeProperty(ctx context.Context, phoneNumber, key string) (interface{}, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p := cayley.StartPath(r.store, quad.String(phoneNumber)).Out(quad.String(key))
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
+
+	if !it.Next(ctx) {
+		return nil, false
+	}
+
+	value := quad.NativeOf(r.store.NameOf(it.Result()))
+	// Integers may be stored as int64
+	if int64Val, ok := value.(int64); ok {
+		value = int(int64Val)
+	}
+	return value, true
+}
+
+// GetSavedContacts returns the phone numbers a user has saved as contacts
+// This is: phoneNumber -has_contact-> ?
+func (r *CayleyGraphRepository) GetSavedContacts(ctx context.Context, phoneNumber string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p := cayley.StartPath(r.store, quad.String(phoneNumber)).Out(quad.String(string(models.EdgeTypeContact)))
+	return r.collectStringsUnsafe(ctx, p)
+}
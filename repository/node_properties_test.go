@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestCayleyGraphRepository_NodeProperties(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	repo.AddNodeWithName(ctx, "7379037972", "John")
+
+	if _, ok := repo.GetNodeProperty(ctx, "7379037972", NodePropertyTrustRank); ok {
+		t.Error("Expected no trust rank before it is set")
+	}
+
+	for _, trust := range []float64{0.25, 0.75} {
+		if err := repo.SetNodeProperties(ctx, "7379037972", map[string]interface{}{NodePropertyTrustRank: trust}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Setting a property replaces the previous value
+	if value, ok := repo.GetNodeProperty(ctx, "7379037972", NodePropertyTrustRank); !ok || value != 0.75 {
+		t.Errorf("Expected trust rank 0.75, got %v", value)
+	}
+
+	// Node structure is untouched
+	if node, err := repo.GetNode(ctx, "7379037972"); err != nil || node.Name != "John" {
+		t.Errorf("Expected node 'John', got %v (err: %v)", node, err)
+	}
+
+	if err := repo.SetNodeProperties(ctx, "0000000000", map[string]interface{}{NodePropertyTrustRank: 0.5}); err != ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+
+	for _, key := range []string{"name", "type", string(models.EdgeTypeContact)} {
+		if err := repo.SetNodeProperties(ctx, "7379037972", map[string]interface{}{key: "x"}); !errors.Is(err, ErrInvalidNodeProperty) {
+			t.Errorf("Expected ErrInvalidNodeProperty for key %q, got %v", key, err)
+		}
+	}
+}
+
+func TestCayleyGraphRepository_GetSavedContacts(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "9876543210", &models.ContactMetadata{Name: "Jane", AddedAt: time.Now()})
+	repo.AddEdgeWithMetadata(ctx, "5555555555", "7379037972", &models.ContactMetadata{Name: "John", AddedAt: time.Now()})
+
+	contacts := repo.GetSavedContacts(ctx, "7379037972")
+	if len(contacts) != 1 || contacts[0] != "9876543210" {
+		t.Errorf("Expected [9876543210], got %v", contacts)
+	}
+}
+
+func TestCayleyGraphRepository_SetNodePropertiesBatch(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "graph-snapshot.json.gz")
+	logPath := filepath.Join(dir, "graph.wal")
+	ctx := context.Background()
+
+	repo := NewInMemoryGraphRepository()
+	if err := repo.EnableMutationLog(ctx, snapshotPath, logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	repo.AddNode(ctx, "7379037972")
+	repo.AddNode(ctx, "9876543210")
+	repo.SetNodeProperties(ctx, "7379037972", map[string]interface{}{NodePropertyTrustRank: 0.1})
+
+	// Missing nodes are skipped, existing values are replaced
+	err := repo.SetNodePropertiesBatch(ctx, map[string]map[string]interface{}{
+		"7379037972": {NodePropertyTrustRank: 0.25},
+		"9876543210": {NodePropertyTrustRank: 0.75, "saves": 3},
+		"0000000000": {NodePropertyTrustRank: 0.5},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := repo.SetNodePropertiesBatch(ctx, map[string]map[string]interface{}{"7379037972": {"name": "x"}}); !errors.Is(err, ErrInvalidNodeProperty) {
+		t.Errorf("Expected ErrInvalidNodeProperty, got %v", err)
+	}
+	repo.Close()
+
+	// The batch is logged as one entry
+	wal, entries, err := OpenMutationLog(logPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wal.Close()
+
+	if len(entries) != 4 || len(entries[3].NodeProperties) != 2 {
+		t.Errorf("Expected 2 add_node entries, 1 set and 1 batch entry for 2 nodes, got %d entries", len(entries))
+	}
+
+	// The batch is recovered from the log with its value types
+	recovered := NewInMemoryGraphRepository()
+	if err := recovered.EnableMutationLog(ctx, snapshotPath, logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer recovered.Close()
+
+	for phoneNumber, expected := range map[string]float64{"7379037972": 0.25, "9876543210": 0.75} {
+		if value, ok := recovered.GetNodeProperty(ctx, phoneNumber, NodePropertyTrustRank); !ok || value != expected {
+			t.Errorf("Expected trust rank %v for %s, got %v", expected, phoneNumber, value)
+		}
+	}
+
+	if value, _ := recovered.GetNodeProperty(ctx, "9876543210", "saves"); value != 3 {
+		t.Errorf("Expected int property 3 to keep its type, got %v (%T)", value, value)
+	}
+
+	if recovered.NodeExists(ctx, "0000000000") {
+		t.Error("Expected the missing node not to be created")
+	}
+}
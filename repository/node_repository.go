@@ -14,4 +14,7 @@ type NodeRepository interface {
 	NodeExists(ctx context.Context, phoneNumber string) bool
 	GetAllNodes(ctx context.Context) ([]*models.Node, error)
 	DeleteNode(ctx context.Context, phoneNumber string) error
+	SetNodeProperties(ctx context.Context, phoneNumber string, properties map[string]interface{}) error
+	SetNodePropertiesBatch(ctx context.Context, properties map[string]map[string]interface{}) error
+	GetNodeProperty(ctx context.Context, phoneNumber, key string) (interface{}, bool)
 }
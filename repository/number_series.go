@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+// SeriesStats holds aggregates over the numbers of a number series (all numbers sharing a prefix)
+type SeriesStats struct {
+	Prefix       string `json:"prefix"`
+	NumberCount  int    `json:"number_count"`  // Numbers in the series
+	SpamReports  int    `json:"spam_reports"`  // Spam reports filed against numbers in the series
+	ContactSaves int    `json:"contact_saves"` // Times numbers in the series were saved as contacts
+	CallVolume   int    `json:"call_volume"`   // Calls made or received by numbers in the series
+}
+
+// prefixIndex keeps node phone numbers sorted, so the numbers sharing a prefix
+// are a contiguous range found with a binary search
+type prefixIndex struct {
+	numbers []string
+}
+
+// newPrefixIndex creates an empty prefix index
+func newPrefixIndex() *prefixIndex {
+	return &prefixIndex{
+		numbers: make([]string, 0),
+	}
+}
+
+// add indexes a phone number if it is not already indexed
+func (ix *prefixIndex) add(phoneNumber string) {
+	i := sort.SearchStrings(ix.numbers, phoneNumber)
+	if i < len(ix.numbers) && ix.numbers[i] == phoneNumber {
+		return
+	}
+
+	ix.numbers = append(ix.numbers, "")
+	copy(ix.numbers[i+1:], ix.numbers[i:])
+	ix.numbers[i] = phoneNumber
+}
+
+// remove drops a phone number from the index
+func (ix *prefixIndex) remove(phoneNumber string) {
+	i := sort.SearchStrings(ix.numbers, phoneNumber)
+	if i < len(ix.numbers) && ix.numbers[i] == phoneNumber {
+		ix.numbers = append(ix.numbers[:i], ix.numbers[i+1:]...)
+	}
+}
+
+// withPrefix returns the indexed phone numbers starting with prefix, in sorted order
+func (ix *prefixIndex) withPrefix(prefix string) []string {
+	start := sort.SearchStrings(ix.numbers, prefix)
+
+	end := start
+	for end < len(ix.numbers) && strings.HasPrefix(ix.numbers[end], prefix) {
+		end++
+	}
+
+	result := make([]string, end-start)
+	copy(result, ix.numbers[start:end])
+	return result
+}
+
+// GetNumbersWithPrefix returns the phone numbers of all nodes starting with prefix
+func (r *CayleyGraphRepository) GetNumbersWithPrefix(ctx context.Context, prefix string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.numbers.withPrefix(prefix)
+}
+
+// GetSeriesStats returns aggregates over the number series sharing prefix
+// Served from the prefix index and degree counters, so the cost is linear in the series size
+func (r *CayleyGraphRepository) GetSeriesStats(ctx context.Context, prefix string) *SeriesStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := &SeriesStats{
+		Prefix: prefix,
+	}
+
+	for _, phoneNumber := range r.numbers.withPrefix(prefix) {
+		stats.NumberCount++
+		stats.SpamReports += r.degrees.get(phoneNumber, models.EdgeTypeSpamReport, DirectionIncoming)
+		stats.ContactSaves += r.degrees.get(phoneNumber, models.EdgeTypeContact, DirectionIncoming)
+		stats.CallVolume += r.degrees.get(phoneNumber, models.EdgeTypeCall, DirectionBoth)
+	}
+
+	return stats
+}
+
+// rebuildPrefixIndexUnsafe rebuilds the prefix index from the store (must be called with lock held)
+func (r *CayleyGraphRepository) rebuildPrefixIndexUnsafe(ctx context.Context) error {
+	p := cayley.StartPath(r.store).Has(quad.String("type"), quad.String("node"))
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
+
+	numbers := make([]string, 0)
+	for it.Next(ctx) {
+		numbers = append(numbers, quad.ToString(r.store.NameOf(it.Result())))
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	sort.Strings(numbers)
+	r.numbers = &prefixIndex{numbers: numbers}
+	return nil
+}
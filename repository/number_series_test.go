@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"credCode/models"
+)
+
+func TestPrefixIndex(t *testing.T) {
+	ix := newPrefixIndex()
+
+	for _, number := range []string{"14022000002", "7379037972", "14022000001", "14023000001", "14022000001"} {
+		ix.add(number)
+	}
+
+	numbers := ix.withPrefix("14022")
+	if len(numbers) != 2 || numbers[0] != "14022000001" || numbers[1] != "14022000002" {
+		t.Errorf("Expected [14022000001 14022000002], got %v", numbers)
+	}
+
+	ix.remove("14022000001")
+	if numbers := ix.withPrefix("1402"); len(numbers) != 2 {
+		t.Errorf("Expected 2 numbers after remove, got %v", numbers)
+	}
+
+	if numbers := ix.withPrefix("999"); len(numbers) != 0 {
+		t.Errorf("Expected no numbers for unknown prefix, got %v", numbers)
+	}
+}
+
+func TestCayleyGraphRepository_GetSeriesStats(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	repo.AddNode(ctx, "14022000001")
+	repo.AddCallEdge("14022000001", "7379037972", true, 5, time.Now())
+	repo.AddCallEdge("14022000002", "7379037972", true, 5, time.Now())
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "14022000002", &models.SpamReportMetadata{Category: models.SpamCategoryRobocall})
+	repo.AddEdgeWithMetadata(ctx, "7379037972", "14022000003", &models.ContactMetadata{Name: "Shop", AddedAt: time.Now()})
+
+	stats := repo.GetSeriesStats(ctx, "14022")
+	if stats.NumberCount != 3 || stats.SpamReports != 1 || stats.ContactSaves != 1 || stats.CallVolume != 2 {
+		t.Errorf("Unexpected series stats: %+v", stats)
+	}
+
+	// Deleting a node removes it from the series
+	if err := repo.DeleteNode(ctx, "14022000001"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if numbers := repo.GetNumbersWithPrefix(ctx, "14022"); len(numbers) != 2 {
+		t.Errorf("Expected 2 numbers after delete, got %v", numbers)
+	}
+}
+
+func TestPrefixIndex_RebuiltOnRestore(t *testing.T) {
+	source := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	source.AddNode(ctx, "14022000001")
+	source.AddNode(ctx, "14022000002")
+	source.AddNode(ctx, "7379037972")
+
+	var buf bytes.Buffer
+	if err := source.Snapshot(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	target := NewInMemoryGraphRepository()
+	if err := target.Restore(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if numbers := target.GetNumbersWithPrefix(ctx, "14022"); len(numbers) != 2 {
+		t.Errorf("Expected 2 numbers after restore, got %v", numbers)
+	}
+}
@@ -9,9 +9,15 @@ import (
 // QueryRepository defines the interface for graph query operations
 type QueryRepository interface {
 	GetUsersWithContact(ctx context.Context, phoneNumber string) ([]string, int)
+	GetSavedContacts(ctx context.Context, phoneNumber string) []string
 	GetOutgoingEdges(ctx context.Context, phoneNumber string, edgeType models.EdgeType) []*models.Edge
 	GetIncomingEdges(ctx context.Context, phoneNumber string, edgeType models.EdgeType) []*models.Edge
+	GetDegree(ctx context.Context, phoneNumber string, edgeType models.EdgeType, direction string) int
 	GetCallsWithFilters(ctx context.Context, phoneNumber string, filters CallFilters, direction string) ([]*models.Edge, int)
+	GetMessagesWithFilters(ctx context.Context, phoneNumber string, filters MessageFilters, direction string) ([]*models.Edge, int)
 	IsDirectContact(ctx context.Context, userPhone, callerPhone string) bool
 	GetSecondLevelContactCount(ctx context.Context, userPhone, callerPhone string) int
+	GetReciprocatedNumbers(ctx context.Context, phoneNumber string, numbers []string) map[string]bool
+	GetNumbersWithPrefix(ctx context.Context, prefix string) []string
+	GetSeriesStats(ctx context.Context, prefix string) *SeriesStats
 }
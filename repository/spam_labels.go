@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"credCode/models"
+
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+)
+
+var ErrSpamLabelNotFound = errors.New("number is not labeled as spam")
+
+// spamLabelPredicate links a labeled number to the source of its label: phoneNumber -> spam_label -> source
+const spamLabelPredicate = "spam_label"
+
+// SpamLabelRepository defines the collection of numbers confirmed as spam by operators
+type SpamLabelRepository interface {
+	LabelSpamNumber(ctx context.Context, phoneNumber, source string) error
+	UnlabelSpamNumber(ctx context.Context, phoneNumber string) error
+	IsLabeledSpam(ctx context.Context, phoneNumber string) bool
+	GetSpamLabels(ctx context.Context) []models.SpamLabel
+}
+
+// LabelSpamNumber labels a number as confirmed spam, replacing any previous label
+// The node is created if the number is not in the graph yet (labels often come from external lists)
+func (r *CayleyGraphRepository) LabelSpamNumber(ctx context.Context, phoneNumber, source string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if phoneNumber == "" {
+		return fmt.Errorf("%w: phone number is required", ErrInvalidNodeProperty)
+	}
+	if source == "" {
+		source = models.SpamLabelSourceManual
+	}
+
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:          MutationLabelSpam,
+		PhoneNumber: phoneNumber,
+		Properties:  map[string]interface{}{"source": source},
+	}); err != nil {
+		return err
+	}
+
+	return r.applyLabelSpamUnsafe(ctx, phoneNumber, source)
+}
+
+// applyLabelSpamUnsafe writes the label quad of a number (must be called with lock held)
+func (r *CayleyGraphRepository) applyLabelSpamUnsafe(ctx context.Context, phoneNumber, source string) error {
+	if !r.nodeExistsUnsafe(phoneNumber) {
+		if err := r.applyAddNodeUnsafe(phoneNumber, ""); err != nil {
+			return err
+		}
+	}
+
+	return r.applySetNodePropertiesUnsafe(ctx, phoneNumber, map[string]interface{}{
+		spamLabelPredicate: source,
+	})
+}
+
+// UnlabelSpamNumber removes the spam label of a number
+func (r *CayleyGraphRepository) UnlabelSpamNumber(ctx context.Context, phoneNumber string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.spamLabelUnsafe(ctx, phoneNumber); !ok {
+		return ErrSpamLabelNotFound
+	}
+
+	// Log the mutation before applying it
+	if err := r.logMutationUnsafe(&MutationLogEntry{
+		Op:          MutationUnlabelSpam,
+		PhoneNumber: phoneNumber,
+	}); err != nil {
+		return err
+	}
+
+	return r.applyUnlabelSpamUnsafe(ctx, phoneNumber)
+}
+
+// applyUnlabelSpamUnsafe removes the label quad of a number (must be called with lock held)
+func (r *CayleyGraphRepository) applyUnlabelSpamUnsafe(ctx context.Context, phoneNumber string) error {
+	source, ok := r.spamLabelUnsafe(ctx, phoneNumber)
+	if !ok {
+		return ErrSpamLabelNotFound
+	}
+
+	tx := cayley.NewTransaction()
+	tx.RemoveQuad(quad.Make(phoneNumber, spamLabelPredicate, source, nil))
+	if err := r.store.ApplyTransaction(tx); err != nil {
+		return fmt.Errorf("failed to remove spam label: %w", err)
+	}
+
+	return nil
+}
+
+// IsLabeledSpam reports whether a number is labeled as confirmed spam
+func (r *CayleyGraphRepository) IsLabeledSpam(ctx context.Context, phoneNumber string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.spamLabelUnsafe(ctx, phoneNumber)
+	return ok
+}
+
+// GetSpamLabels returns all labeled spam numbers, sorted by phone number
+func (r *CayleyGraphRepository) GetSpamLabels(ctx context.Context) []models.SpamLabel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	labels := make([]models.SpamLabel, 0)
+
+	p := cayley.StartPath(r.store).Has(quad.String(spamLabelPredicate))
+	for _, phoneNumber := range r.collectStringsUnsafe(ctx, p) {
+		if source, ok := r.spamLabelUnsafe(ctx, phoneNumber); ok {
+			labels = append(labels, models.SpamLabel{
+				PhoneNumber: phoneNumber,
+				Source:      source,
+			})
+		}
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].PhoneNumber < labels[j].PhoneNumber
+	})
+
+	return labels
+}
+
+// spamLabelUnsafe returns the label source of a number, and whether it is labeled (must be called with lock held)
+func (r *CayleyGraphRepository) spamLabelUnsafe(ctx context.Context, phoneNumber string) (string, bool) {
+	p := cayley.StartPath(r.store, quad.String(phoneNumber)).Out(quad.String(spamLabelPredicate))
+	it, _ := p.BuildIterator().Optimize()
+	defer it.Close()
+
+	if !it.Next(ctx) {
+		return "", false
+	}
+
+	return quad.ToString(r.store.NameOf(it.Result())), true
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"credCode/models"
+)
+
+func TestCayleyGraphRepository_SpamLabels(t *testing.T) {
+	repo := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	repo.AddNodeWithName(ctx, "14022000001", "Offers")
+
+	if repo.IsLabeledSpam(ctx, "14022000001") {
+		t.Error("Expected number to be unlabeled initially")
+	}
+
+	// Labeling an unknown number adds it to the graph
+	if err := repo.LabelSpamNumber(ctx, "14022000002", "fcc"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !repo.NodeExists(ctx, "14022000002") {
+		t.Error("Expected labeled number to be added as a node")
+	}
+
+	// Relabeling replaces the source
+	repo.LabelSpamNumber(ctx, "14022000001", "fcc")
+	repo.LabelSpamNumber(ctx, "14022000001", "")
+
+	labels := repo.GetSpamLabels(ctx)
+	if len(labels) != 2 {
+		t.Fatalf("Expected 2 labels, got %v", labels)
+	}
+	if labels[0].PhoneNumber != "14022000001" || labels[0].Source != models.SpamLabelSourceManual {
+		t.Errorf("Expected 14022000001 labeled manually, got %+v", labels[0])
+	}
+
+	// The node keeps its name
+	if node, _ := repo.GetNode(ctx, "14022000001"); node.Name != "Offers" {
+		t.Errorf("Expected name 'Offers', got '%s'", node.Name)
+	}
+
+	if err := repo.UnlabelSpamNumber(ctx, "14022000001"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if repo.IsLabeledSpam(ctx, "14022000001") {
+		t.Error("Expected label to be removed")
+	}
+	if err := repo.UnlabelSpamNumber(ctx, "14022000001"); !errors.Is(err, ErrSpamLabelNotFound) {
+		t.Errorf("Expected ErrSpamLabelNotFound, got %v", err)
+	}
+
+	// Labels cannot be written as plain node properties
+	if err := repo.SetNodeProperties(ctx, "14022000001", map[string]interface{}{"spam_label": "x"}); !errors.Is(err, ErrInvalidNodeProperty) {
+		t.Errorf("Expected ErrInvalidNodeProperty, got %v", err)
+	}
+}
+
+func TestCayleyGraphRepository_SpamLabels_Snapshot(t *testing.T) {
+	source := NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	source.LabelSpamNumber(ctx, "14022000001", "fcc")
+
+	var buf bytes.Buffer
+	if err := source.Snapshot(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	target := NewInMemoryGraphRepository()
+	if err := target.Restore(ctx, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !target.IsLabeledSpam(ctx, "14022000001") {
+		t.Error("Expected spam label to survive a snapshot")
+	}
+}
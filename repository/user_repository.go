@@ -36,13 +36,17 @@ type UserRepository interface {
 
 	// Seed data operations
 	LoadSeedData(ctx context.Context, filePath string) error
+
+	// Name search
+	NameIndexer
 }
 
 // InMemoryUserRepository implements UserRepository with in-memory storage
 type InMemoryUserRepository struct {
-	users  map[string]*models.User // key: user ID
-	phones map[string]string       // key: phone number, value: user ID
-	mu     sync.RWMutex
+	users     map[string]*models.User // key: user ID
+	phones    map[string]string       // key: phone number, value: user ID
+	nameIndex *NameIndex              // nil unless a name index is attached
+	mu        sync.RWMutex
 }
 
 // NewInMemoryUserRepository creates a new in-memory user repository
@@ -68,6 +72,7 @@ func (r *InMemoryUserRepository) CreateUser(ctx context.Context, user *models.Us
 
 	r.users[user.ID] = user
 	r.phones[user.PhoneNumber] = user.ID
+	r.indexUserUnsafe(user)
 	return nil
 }
 
@@ -128,7 +133,9 @@ func (r *InMemoryUserRepository) UpdateUser(ctx context.Context, user *models.Us
 		r.phones[user.PhoneNumber] = user.ID
 	}
 
+	r.unindexUserUnsafe(existingUser)
 	r.users[user.ID] = user
+	r.indexUserUnsafe(user)
 	return nil
 }
 
@@ -144,6 +151,7 @@ func (r *InMemoryUserRepository) DeleteUser(ctx context.Context, id string) erro
 
 	delete(r.users, id)
 	delete(r.phones, user.PhoneNumber)
+	r.unindexUserUnsafe(user)
 	return nil
 }
 
@@ -165,6 +173,7 @@ func (r *InMemoryUserRepository) AddContact(ctx context.Context, userID string,
 	}
 
 	user.Contacts = append(user.Contacts, contact)
+	r.indexContactUnsafe(contact)
 	return nil
 }
 
@@ -212,7 +221,9 @@ func (r *InMemoryUserRepository) UpdateContact(ctx context.Context, userID strin
 
 	for i, c := range user.Contacts {
 		if c.ID == contact.ID {
+			r.unindexContactUnsafe(c)
 			user.Contacts[i] = contact
+			r.indexContactUnsafe(contact)
 			return nil
 		}
 	}
@@ -233,6 +244,7 @@ func (r *InMemoryUserRepository) DeleteContact(ctx context.Context, userID, cont
 	for i, contact := range user.Contacts {
 		if contact.ID == contactID {
 			user.Contacts = append(user.Contacts[:i], user.Contacts[i+1:]...)
+			r.unindexContactUnsafe(contact)
 			return nil
 		}
 	}
@@ -262,9 +274,62 @@ func (r *InMemoryUserRepository) LoadSeedData(ctx context.Context, filePath stri
 
 	// Load users into the repository
 	for _, user := range seedData.Users {
+		if existing, exists := r.users[user.ID]; exists {
+			r.unindexUserUnsafe(existing)
+		}
 		r.users[user.ID] = user
 		r.phones[user.PhoneNumber] = user.ID
+		r.indexUserUnsafe(user)
 	}
 
 	return nil
 }
+
+// SetNameIndex indexes user and contact names and keeps the index updated as users and contacts change
+func (r *InMemoryUserRepository) SetNameIndex(index *NameIndex) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nameIndex = index
+	for _, user := range r.users {
+		r.indexUserUnsafe(user)
+	}
+}
+
+// indexUserUnsafe adds a user's name and contact names to the name index (must be called with lock held)
+func (r *InMemoryUserRepository) indexUserUnsafe(user *models.User) {
+	if r.nameIndex == nil {
+		return
+	}
+
+	r.nameIndex.Add(user.PhoneNumber, user.Name, models.NameSourceUser)
+	for _, contact := range user.Contacts {
+		r.indexContactUnsafe(contact)
+	}
+}
+
+// unindexUserUnsafe removes a user's name and contact names from the name index (must be called with lock held)
+func (r *InMemoryUserRepository) unindexUserUnsafe(user *models.User) {
+	if r.nameIndex == nil {
+		return
+	}
+
+	r.nameIndex.Remove(user.PhoneNumber, user.Name, models.NameSourceUser)
+	for _, contact := range user.Contacts {
+		r.unindexContactUnsafe(contact)
+	}
+}
+
+// indexContactUnsafe adds the name a contact was saved under to the name index (must be called with lock held)
+func (r *InMemoryUserRepository) indexContactUnsafe(contact *models.Contact) {
+	if r.nameIndex != nil {
+		r.nameIndex.Add(contact.PhoneNumber, contact.Name, models.NameSourceContact)
+	}
+}
+
+// unindexContactUnsafe removes the name a contact was saved under from the name index (must be called with lock held)
+func (r *InMemoryUserRepository) unindexContactUnsafe(contact *models.Contact) {
+	if r.nameIndex != nil {
+		r.nameIndex.Remove(contact.PhoneNumber, contact.Name, models.NameSourceContact)
+	}
+}
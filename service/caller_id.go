@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// CallerIDService derives a number's display name from the names users saved it under
+// Names are normalised, near-duplicates ("Jon Smith", "john smith", "Mr. John Smith") are
+// clustered, and the largest cluster wins; the rest are returned as alternatives
+type CallerIDService struct {
+	graphRepo  repository.GraphRepository
+	similarity float64 // Normalised edit similarity at which two names are the same (e.g., 0.8)
+}
+
+// NewCallerIDService creates a new caller-ID service
+func NewCallerIDService(graphRepo repository.GraphRepository, similarity float64) *CallerIDService {
+	return &CallerIDService{
+		graphRepo:  graphRepo,
+		similarity: similarity,
+	}
+}
+
+// nameCluster is a group of near-duplicate names
+type nameCluster struct {
+	key       string         // Normalised name of the cluster's first member
+	spellings map[string]int // Saved spelling -> count
+	count     int
+}
+
+// Lookup returns the consensus display name of a phone number
+// Returns repository.ErrNodeNotFound if the number is not in the graph
+func (s *CallerIDService) Lookup(ctx context.Context, phoneNumber string) (*models.CallerID, error) {
+	if !s.graphRepo.NodeExists(ctx, phoneNumber) {
+		return nil, repository.ErrNodeNotFound
+	}
+
+	result := &models.CallerID{
+		PhoneNumber:  phoneNumber,
+		Alternatives: make([]models.NameCandidate, 0),
+	}
+
+	// Query: What every user saved the number as (inbound has_contact edges)
+	clusters := make([]*nameCluster, 0)
+	for _, edge := range s.graphRepo.GetIncomingEdges(ctx, phoneNumber, models.EdgeTypeContact) {
+		contact, ok := edge.Metadata.(*models.ContactMetadata)
+		if !ok {
+			continue
+		}
+
+		spelling := strings.Join(strings.Fields(contact.Name), " ")
+		key := models.NormaliseName(spelling)
+		if key == "" {
+			continue
+		}
+		result.SavedBy++
+
+		cluster := s.findCluster(clusters, key)
+		if cluster == nil {
+			cluster = &nameCluster{key: key, spellings: make(map[string]int)}
+			clusters = append(clusters, cluster)
+		}
+		cluster.spellings[spelling]++
+		cluster.count++
+	}
+
+	if len(clusters) == 0 {
+		return result, nil
+	}
+
+	// Largest cluster first; ties broken by name for a stable answer
+	candidates := make([]models.NameCandidate, len(clusters))
+	for i, cluster := range clusters {
+		candidates[i] = models.NameCandidate{
+			Name:  cluster.displayName(),
+			Count: cluster.count,
+			Share: float64(cluster.count) / float64(result.SavedBy),
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Count != candidates[j].Count {
+			return candidates[i].Count > candidates[j].Count
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	// Confidence: the winner's share, discounted when few users saved the number
+	best := candidates[0]
+	result.Name = best.Name
+	result.Confidence = best.Share * float64(result.SavedBy) / float64(result.SavedBy+1)
+	result.Alternatives = candidates[1:]
+
+	return result, nil
+}
+
+// findCluster returns the cluster a normalised name belongs to, or nil
+func (s *CallerIDService) findCluster(clusters []*nameCluster, key string) *nameCluster {
+	var best *nameCluster
+	var bestSimilarity float64
+
+	for _, cluster := range clusters {
+		similarity := models.NameSimilarity(key, cluster.key)
+		if similarity >= s.similarity && similarity > bestSimilarity {
+			best, bestSimilarity = cluster, similarity
+		}
+	}
+
+	return best
+}
+
+// displayName returns the most common spelling in the cluster
+func (c *nameCluster) displayName() string {
+	var name string
+	count := 0
+	for spelling, n := range c.spellings {
+		if n > count || (n == count && spelling < name) {
+			name, count = spelling, n
+		}
+	}
+	return name
+}
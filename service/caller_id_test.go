@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// saveContact adds a has_contact edge from saver to phoneNumber under name
+func saveContact(t *testing.T, graphRepo repository.GraphRepository, saver, phoneNumber, name string) {
+	t.Helper()
+	if _, err := graphRepo.AddEdgeWithMetadata(context.Background(), saver, phoneNumber, &models.ContactMetadata{
+		Name:    name,
+		AddedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("Failed to add contact: %v", err)
+	}
+}
+
+func TestCallerIDService_Lookup(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	svc := NewCallerIDService(graphRepo, 0.8)
+
+	names := []string{"John Smith", "John Smith", "john  smith", "Mr. John Smith", "Jon Smith", "Smith John", "Plumber", "Plumber", "JS"}
+	for i, name := range names {
+		saveContact(t, graphRepo, fmt.Sprintf("98765432%02d", i), "7379037972", name)
+	}
+
+	result, err := svc.Lookup(context.Background(), "7379037972")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Name != "John Smith" {
+		t.Errorf("Expected name 'John Smith', got '%s'", result.Name)
+	}
+	if result.SavedBy != 9 {
+		t.Errorf("Expected 9 savers, got %d", result.SavedBy)
+	}
+
+	// 6 of 9 savers agree, discounted by 9/10 for the sample size
+	if result.Confidence < 0.599 || result.Confidence > 0.601 {
+		t.Errorf("Expected confidence 0.6, got %f", result.Confidence)
+	}
+
+	if len(result.Alternatives) != 2 || result.Alternatives[0].Name != "Plumber" || result.Alternatives[0].Count != 2 {
+		t.Errorf("Expected alternatives [Plumber JS], got %+v", result.Alternatives)
+	}
+}
+
+func TestCallerIDService_Lookup_NoNames(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	graphRepo.AddNode(context.Background(), "7379037972")
+	svc := NewCallerIDService(graphRepo, 0.8)
+
+	result, err := svc.Lookup(context.Background(), "7379037972")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Name != "" || result.Confidence != 0 || len(result.Alternatives) != 0 {
+		t.Errorf("Expected an empty caller ID, got %+v", result)
+	}
+
+	if _, err := svc.Lookup(context.Background(), "0000000000"); err != repository.ErrNodeNotFound {
+		t.Errorf("Expected ErrNodeNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// BlockRule evaluates spam score based on block-list edges
+// If the requesting user blocked the caller, the number is spam for them (hard verdict)
+// Otherwise, more distinct users blocking the caller = higher spam score
+type BlockRule struct {
+	threshold int     // Distinct blockers at which the global signal reaches maxScore
+	maxScore  float64 // Maximum spam score from the global signal
+}
+
+// NewBlockRule creates a new block rule
+func NewBlockRule(threshold int, maxScore float64) service.SpamRule {
+	return &BlockRule{
+		threshold: threshold,
+		maxScore:  maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *BlockRule) Name() string {
+	return "block_rule"
+}
+
+// Evaluate evaluates the block rule
+func (r *BlockRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// Step 1: Has the requesting user blocked the caller?
+	if userPhoneNumber != "" {
+		if _, err := graphRepo.FindEdge(ctx, userPhoneNumber, phoneNumber, models.EdgeTypeBlock); err == nil {
+			return &models.SpamScore{
+				RuleName: r.Name(),
+				Score:    1.0,
+				Reason:   "Caller is blocked by the user",
+			}, nil
+		}
+	}
+
+	// Step 2: How many users have blocked the caller? (block edges are unique per user, O(1))
+	count := graphRepo.GetDegree(ctx, phoneNumber, models.EdgeTypeBlock, repository.DirectionIncoming)
+
+	if count == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        "Phone number not blocked by any user",
+			NotApplicable: true,
+		}, nil
+	}
+
+	var score float64
+	var reason string
+
+	if count < r.threshold {
+		// Below threshold - score increases with the number of blockers
+		score = r.maxScore * float64(count) / float64(r.threshold)
+		reason = fmt.Sprintf("Phone number blocked by %d user(s) (below threshold of %d)", count, r.threshold)
+	} else {
+		score = r.maxScore
+		reason = fmt.Sprintf("Phone number blocked by %d users (widely blocked)", count)
+	}
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// Ensure BlockRule implements SpamRule interface
+var _ service.SpamRule = (*BlockRule)(nil)
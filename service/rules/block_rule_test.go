@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// addBlock makes user block phoneNumber
+func addBlock(t *testing.T, graphRepo repository.GraphRepository, user, phoneNumber string) {
+	if _, err := graphRepo.AddEdgeWithMetadata(context.Background(), user, phoneNumber, &models.BlockMetadata{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestBlockRule_Name(t *testing.T) {
+	rule := NewBlockRule(5, 0.8)
+
+	if rule.Name() != "block_rule" {
+		t.Errorf("Expected name 'block_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestBlockRule_Evaluate_BlockedByUser(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewBlockRule(5, 0.8)
+	ctx := context.Background()
+
+	addBlock(t, graphRepo, "7379037972", "14022000001")
+
+	score, err := rule.Evaluate(ctx, "14022000001", "7379037972", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 1.0 {
+		t.Errorf("Expected hard verdict 1.0 for a caller the user blocked, got %f", score.Score)
+	}
+
+	// Another user only sees the global signal from one blocker
+	score, err = rule.Evaluate(ctx, "14022000001", "9876543210", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score < 0.159 || score.Score > 0.161 {
+		t.Errorf("Expected score 0.16 for one blocker, got %f", score.Score)
+	}
+}
+
+func TestBlockRule_Evaluate_GlobalSignal(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewBlockRule(5, 0.8)
+	ctx := context.Background()
+
+	score, err := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 0.0 || !score.NotApplicable {
+		t.Errorf("Expected not applicable score 0.0 for no blockers, got %f (not applicable: %v)", score.Score, score.NotApplicable)
+	}
+
+	for i := 0; i < 6; i++ {
+		addBlock(t, graphRepo, fmt.Sprintf("555000000%d", i), "14022000001")
+	}
+
+	score, err = rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 0.8 {
+		t.Errorf("Expected maxScore 0.8 for a widely blocked number, got %f", score.Score)
+	}
+}
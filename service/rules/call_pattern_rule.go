@@ -54,6 +54,19 @@ func (r *CallPatternRule) Evaluate(ctx context.Context, phoneNumber string, user
 	var reason string
 
 	if count == 0 {
+		// No calls at all - nothing to judge
+		_, totalCount := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+			TimeRangeStart: &timeStart,
+		}, "both")
+		if totalCount == 0 {
+			return &models.SpamScore{
+				RuleName:      r.Name(),
+				Score:         0.0,
+				Reason:        fmt.Sprintf("No calls in last %v", r.timeWindow),
+				NotApplicable: true,
+			}, nil
+		}
+
 		// No suspicious calls - low spam score
 		score = 0.0
 		reason = fmt.Sprintf("No suspicious call patterns found in last %v", r.timeWindow)
@@ -42,10 +42,13 @@ func TestCallPatternRule_Evaluate_NoCalls(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 
-	// Should have low spam score for no calls
+	// Should have low spam score for no calls, and not count towards the average
 	if score.Score > 0.1 {
 		t.Errorf("Expected low score (< 0.1) for no calls, got %f", score.Score)
 	}
+	if !score.NotApplicable {
+		t.Error("Expected no calls to be not applicable")
+	}
 }
 
 func TestCallPatternRule_Evaluate_SuspiciousPattern(t *testing.T) {
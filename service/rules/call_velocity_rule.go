@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// velocityWindow is a sliding window with a limit on outbound calls
+type velocityWindow struct {
+	size  time.Duration
+	limit int
+}
+
+// CallVelocityRule evaluates spam score based on bursts of outbound calls
+// Counts outgoing calls to numbers that have not saved the caller, over sliding
+// windows of a minute, an hour and a day, and compares the peak of each window to its limit
+type CallVelocityRule struct {
+	windows  []velocityWindow // Windows checked, shortest first; a limit of 0 disables the window
+	maxScore float64          // Maximum spam score for calling far above the limits
+}
+
+// NewCallVelocityRule creates a new call velocity rule
+func NewCallVelocityRule(perMinute, perHour, perDay int, maxScore float64) service.SpamRule {
+	return &CallVelocityRule{
+		windows: []velocityWindow{
+			{size: time.Minute, limit: perMinute},
+			{size: time.Hour, limit: perHour},
+			{size: 24 * time.Hour, limit: perDay},
+		},
+		maxScore: maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *CallVelocityRule) Name() string {
+	return "call_velocity_rule"
+}
+
+// Evaluate evaluates the call velocity rule
+func (r *CallVelocityRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// Query: Get outgoing calls within the longest window
+	timeStart := time.Now().Add(-r.windows[len(r.windows)-1].size)
+
+	calls, _ := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+		TimeRangeStart: &timeStart,
+	}, "outgoing")
+
+	if len(calls) == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("No outbound calls in last %v", r.windows[len(r.windows)-1].size),
+			NotApplicable: true,
+		}, nil
+	}
+
+	// Query: Who has saved the caller (one lookup instead of one per callee)
+	savers, _ := graphRepo.GetUsersWithContact(ctx, phoneNumber)
+	saved := make(map[string]bool, len(savers))
+	for _, saver := range savers {
+		saved[saver] = true
+	}
+
+	// Keep only calls to numbers that have not saved the caller
+	timestamps := make([]time.Time, 0, len(calls))
+	for _, call := range calls {
+		if !saved[call.To] {
+			timestamps = append(timestamps, call.CreatedAt)
+		}
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	// Find the window whose peak is furthest above its limit
+	var peakWindow velocityWindow
+	peakCount := 0
+	peakRatio := 0.0
+	for _, window := range r.windows {
+		if window.limit <= 0 {
+			continue
+		}
+
+		count := peakInWindow(timestamps, window.size)
+		ratio := float64(count) / float64(window.limit)
+		if ratio > peakRatio {
+			peakWindow, peakCount, peakRatio = window, count, ratio
+		}
+	}
+
+	var score float64
+	var reason string
+
+	if peakCount == 0 {
+		score = 0.0
+		reason = fmt.Sprintf("No outbound calls to non-contacts in last %v", r.windows[len(r.windows)-1].size)
+	} else if peakRatio <= 1.0 {
+		// Within limits - no burst
+		score = 0.0
+		reason = fmt.Sprintf("Peak of %d outbound call(s) to non-contacts within %v (limit %d)",
+			peakCount, peakWindow.size, peakWindow.limit)
+	} else {
+		// Score increases with how far the peak exceeds the limit, capped at maxScore
+		score = r.maxScore * (1.0 - 1.0/peakRatio)
+		reason = fmt.Sprintf("Burst of %d outbound calls to non-contacts within %v (limit %d, %.1fx over)",
+			peakCount, peakWindow.size, peakWindow.limit, peakRatio)
+	}
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// peakInWindow returns the most timestamps falling within any window of the given size
+// Timestamps must be sorted
+func peakInWindow(timestamps []time.Time, size time.Duration) int {
+	peak := 0
+	start := 0
+	for end := range timestamps {
+		for timestamps[end].Sub(timestamps[start]) >= size {
+			start++
+		}
+		if count := end - start + 1; count > peak {
+			peak = count
+		}
+	}
+	return peak
+}
+
+// Ensure CallVelocityRule implements SpamRule interface
+var _ service.SpamRule = (*CallVelocityRule)(nil)
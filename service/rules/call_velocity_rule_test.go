@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// addCall records a call from -> to at the given time
+func addCall(t *testing.T, graphRepo repository.GraphRepository, from, to string, timestamp time.Time) {
+	_, err := graphRepo.AddEdgeWithMetadata(context.Background(), from, to, &models.CallMetadata{
+		IsAnswered:        true,
+		DurationInSeconds: 5,
+		Timestamp:         timestamp,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestCallVelocityRule_Name(t *testing.T) {
+	rule := NewCallVelocityRule(5, 60, 300, 0.9)
+
+	if rule.Name() != "call_velocity_rule" {
+		t.Errorf("Expected name 'call_velocity_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestCallVelocityRule_Evaluate_NoCalls(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewCallVelocityRule(5, 60, 300, 0.9)
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 || !score.NotApplicable {
+		t.Errorf("Expected not applicable score 0.0 without calls, got %f (not applicable: %v)", score.Score, score.NotApplicable)
+	}
+}
+
+func TestCallVelocityRule_Evaluate_NoBurst(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewCallVelocityRule(5, 60, 300, 0.9)
+	ctx := context.Background()
+
+	// A few calls spread over the day
+	for i := 0; i < 4; i++ {
+		addCall(t, graphRepo, "7379037972", fmt.Sprintf("98765432%02d", i), time.Now().Add(-time.Duration(i)*time.Hour))
+	}
+
+	score, err := rule.Evaluate(ctx, "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 within limits, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestCallVelocityRule_Evaluate_Burst(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewCallVelocityRule(5, 60, 300, 0.9)
+	ctx := context.Background()
+
+	// 20 calls within 40 seconds, i.e. 4x the per-minute limit
+	base := time.Now().Add(-10 * time.Minute)
+	for i := 0; i < 20; i++ {
+		addCall(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), base.Add(time.Duration(i*2)*time.Second))
+	}
+
+	score, err := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 0.9 * (1 - 1/4)
+	if score.Score < 0.674 || score.Score > 0.676 {
+		t.Errorf("Expected score 0.675 for a 4x burst, got %f", score.Score)
+	}
+
+	if !strings.Contains(score.Reason, "20 outbound calls") || !strings.Contains(score.Reason, "1m0s") {
+		t.Errorf("Expected reason to report the peak minute window, got '%s'", score.Reason)
+	}
+}
+
+func TestCallVelocityRule_Evaluate_IgnoresContacts(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewCallVelocityRule(5, 60, 300, 0.9)
+	ctx := context.Background()
+
+	// The callee saved the caller, so repeated calls are not cold calls
+	graphRepo.AddEdgeWithMetadata(ctx, "9876543210", "7379037972", &models.ContactMetadata{Name: "Mom", AddedAt: time.Now()})
+
+	base := time.Now().Add(-10 * time.Minute)
+	for i := 0; i < 20; i++ {
+		addCall(t, graphRepo, "7379037972", "9876543210", base.Add(time.Duration(i*2)*time.Second))
+	}
+
+	score, err := rule.Evaluate(ctx, "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 for calls to a contact, got %f", score.Score)
+	}
+}
+
+func TestPeakInWindow(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	timestamps := []time.Time{
+		base,
+		base.Add(30 * time.Second),
+		base.Add(59 * time.Second),
+		base.Add(60 * time.Second), // a minute after the first, so outside its window
+		base.Add(10 * time.Minute),
+	}
+
+	if peak := peakInWindow(timestamps, time.Minute); peak != 3 {
+		t.Errorf("Expected peak 3 within a minute, got %d", peak)
+	}
+	if peak := peakInWindow(timestamps, time.Hour); peak != 5 {
+		t.Errorf("Expected peak 5 within an hour, got %d", peak)
+	}
+	if peak := peakInWindow(nil, time.Hour); peak != 0 {
+		t.Errorf("Expected peak 0 for no calls, got %d", peak)
+	}
+}
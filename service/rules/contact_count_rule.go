@@ -31,8 +31,8 @@ func (r *ContactCountRule) Name() string {
 
 // Evaluate evaluates the contact count rule
 func (r *ContactCountRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
-	// Query: How many users have saved this phone number?
-	_, count := graphRepo.GetUsersWithContact(ctx, phoneNumber)
+	// Query: How many users have saved this phone number? (maintained in-degree, O(1))
+	count := graphRepo.GetDegree(ctx, phoneNumber, models.EdgeTypeContact, repository.DirectionIncoming)
 
 	// Calculate score: fewer contacts = higher spam score
 	var score float64
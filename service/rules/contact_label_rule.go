@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// ContactLabelRule evaluates spam score based on the names users saved a number under
+// Users who get a spam call often save the number as "Spam", "Fraud" or "Don't pick"
+// so they recognise it next time; those names are a crowd-sourced spam label
+type ContactLabelRule struct {
+	lexicon   map[string]float64 // Normalised spam-indicative term -> weight (0.0 to 1.0)
+	minSavers int                // Savers required before the labels are trusted
+	maxScore  float64            // Maximum spam score when every saver labels the number as spam
+}
+
+// NewContactLabelRule creates a new contact label rule
+// lexicon maps spam-indicative terms (any language; phrases match whole words in order) to weights
+func NewContactLabelRule(lexicon map[string]float64, minSavers int, maxScore float64) service.SpamRule {
+	normalised := make(map[string]float64, len(lexicon))
+	for term, weight := range lexicon {
+		if key := normaliseLabel(term); key != "" && weight > normalised[key] {
+			normalised[key] = weight
+		}
+	}
+
+	return &ContactLabelRule{
+		lexicon:   normalised,
+		minSavers: minSavers,
+		maxScore:  maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *ContactLabelRule) Name() string {
+	return "contact_label_rule"
+}
+
+// Evaluate evaluates the contact label rule
+func (r *ContactLabelRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// Query: What every user saved the number as (inbound has_contact edges)
+	edges := graphRepo.GetIncomingEdges(ctx, phoneNumber, models.EdgeTypeContact)
+
+	savers := 0
+	labeled := 0
+	var weighted float64
+	examples := make(map[string]bool)
+
+	for _, edge := range edges {
+		contact, ok := edge.Metadata.(*models.ContactMetadata)
+		if !ok {
+			continue
+		}
+		savers++
+
+		if weight := r.labelWeight(contact.Name); weight > 0 {
+			labeled++
+			weighted += weight
+			examples[strings.TrimSpace(contact.Name)] = true
+		}
+	}
+
+	if savers < r.minSavers {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("Saved by %d user(s), fewer than %d needed to trust contact names", savers, r.minSavers),
+			NotApplicable: true,
+		}, nil
+	}
+
+	if labeled == 0 {
+		return &models.SpamScore{
+			RuleName: r.Name(),
+			Score:    0.0,
+			Reason:   fmt.Sprintf("None of %d user(s) saved the number under a spam label", savers),
+		}, nil
+	}
+
+	// Score: weighted fraction of savers who labeled the number as spam
+	score := r.maxScore * weighted / float64(savers)
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason: fmt.Sprintf("%d of %d user(s) saved the number under a spam label (%s)",
+			labeled, savers, quoteExamples(examples, 3)),
+	}, nil
+}
+
+// labelWeight returns the weight of the strongest lexicon term in a contact name, or 0
+func (r *ContactLabelRule) labelWeight(name string) float64 {
+	padded := " " + normaliseLabel(name) + " "
+
+	var weight float64
+	for term, termWeight := range r.lexicon {
+		if termWeight > weight && strings.Contains(padded, " "+term+" ") {
+			weight = termWeight
+		}
+	}
+
+	return weight
+}
+
+// normaliseLabel lower-cases a name and turns punctuation into word breaks, keeping word order
+// ("Don't PICK!!" becomes "don t pick")
+func normaliseLabel(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// quoteExamples returns up to limit names, quoted and sorted
+func quoteExamples(names map[string]bool, limit int) string {
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	quoted := make([]string, len(sorted))
+	for i, name := range sorted {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Ensure ContactLabelRule implements SpamRule interface
+var _ service.SpamRule = (*ContactLabelRule)(nil)
@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+var testSpamLexicon = map[string]float64{
+	"spam":       1.0,
+	"fraud":      1.0,
+	"don't pick": 0.9,
+	"loan agent": 0.5,
+	"फ्रॉड":      1.0,
+}
+
+// addNamedContact saves phoneNumber in saver's contacts under name
+func addNamedContact(t *testing.T, graphRepo repository.GraphRepository, saver, phoneNumber, name string) {
+	t.Helper()
+	if _, err := graphRepo.AddEdgeWithMetadata(context.Background(), saver, phoneNumber, &models.ContactMetadata{
+		Name:    name,
+		AddedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("Failed to add contact: %v", err)
+	}
+}
+
+func TestContactLabelRule_Name(t *testing.T) {
+	rule := NewContactLabelRule(testSpamLexicon, 2, 0.8)
+
+	if rule.Name() != "contact_label_rule" {
+		t.Errorf("Expected name 'contact_label_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestContactLabelRule_Evaluate_SpamLabels(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewContactLabelRule(testSpamLexicon, 2, 0.8)
+
+	names := []string{"SPAM!!", "Fraud call", "Dont pick", "Don’t pick", "Loan Agent", "फ्रॉड", "Ravi", "Spammy Sam"}
+	for i, name := range names {
+		addNamedContact(t, graphRepo, fmt.Sprintf("98765432%02d", i), "14022000001", name)
+	}
+
+	score, err := rule.Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Matches: spam 1.0, fraud 1.0, don't pick 0.9 (curly apostrophe), loan agent 0.5, फ्रॉड 1.0
+	// "Dont pick" and "Spammy" are not whole-word matches
+	expected := 0.8 * 4.4 / 8
+	if score.Score < expected-0.001 || score.Score > expected+0.001 {
+		t.Errorf("Expected score %f, got %f (%s)", expected, score.Score, score.Reason)
+	}
+
+	if !strings.Contains(score.Reason, "5 of 8") {
+		t.Errorf("Expected reason to count labeled savers, got '%s'", score.Reason)
+	}
+}
+
+func TestContactLabelRule_Evaluate_OrdinaryNames(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewContactLabelRule(testSpamLexicon, 2, 0.8)
+
+	addNamedContact(t, graphRepo, "9876543210", "7379037972", "John")
+	addNamedContact(t, graphRepo, "5555555555", "7379037972", "John Spammer Smith")
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestContactLabelRule_Evaluate_TooFewSavers(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewContactLabelRule(testSpamLexicon, 2, 0.8)
+
+	addNamedContact(t, graphRepo, "9876543210", "14022000001", "Spam")
+
+	score, err := rule.Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 below the minimum savers, got %f", score.Score)
+	}
+}
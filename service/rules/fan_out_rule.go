@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// FanOutRule evaluates spam score based on the shape of a caller's outbound call graph
+// Legit users call a small set of numbers that call back or have them saved;
+// spammers dial many distinct numbers and almost none reciprocate
+type FanOutRule struct {
+	timeWindow      time.Duration // Time window to analyze (e.g., 7 days)
+	fanOutThreshold int           // Distinct callees at which the fan-out is fully suspicious
+	maxScore        float64       // Maximum spam score for a large, one-directional call graph
+}
+
+// NewFanOutRule creates a new fan-out rule
+func NewFanOutRule(timeWindow time.Duration, fanOutThreshold int, maxScore float64) service.SpamRule {
+	return &FanOutRule{
+		timeWindow:      timeWindow,
+		fanOutThreshold: fanOutThreshold,
+		maxScore:        maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *FanOutRule) Name() string {
+	return "fan_out_rule"
+}
+
+// Evaluate evaluates the fan-out rule
+func (r *FanOutRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// Query: Outgoing calls in the time window (the windowed form of GetOutgoingEdges(call))
+	timeStart := time.Now().Add(-r.timeWindow)
+
+	calls, _ := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+		TimeRangeStart: &timeStart,
+	}, "outgoing")
+
+	// Distinct callees
+	seen := make(map[string]bool)
+	callees := make([]string, 0)
+	for _, call := range calls {
+		if !seen[call.To] {
+			seen[call.To] = true
+			callees = append(callees, call.To)
+		}
+	}
+
+	if len(callees) == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("No outbound calls in last %v", r.timeWindow),
+			NotApplicable: true,
+		}, nil
+	}
+
+	// Query: Which callees called back or saved the caller (one batched lookup)
+	reciprocated := graphRepo.GetReciprocatedNumbers(ctx, phoneNumber, callees)
+	ratio := float64(len(reciprocated)) / float64(len(callees))
+
+	// Score: one-directional share, scaled by how large the fan-out is
+	fanOut := math.Min(1.0, float64(len(callees))/float64(r.fanOutThreshold))
+	score := r.maxScore * (1.0 - ratio) * fanOut
+
+	reason := fmt.Sprintf("Called %d distinct number(s) in last %v, %d (%.0f%%) reciprocated by calling back or saving the caller",
+		len(callees), r.timeWindow, len(reciprocated), ratio*100)
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// Ensure FanOutRule implements SpamRule interface
+var _ service.SpamRule = (*FanOutRule)(nil)
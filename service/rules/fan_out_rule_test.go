@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+func TestFanOutRule_Name(t *testing.T) {
+	rule := NewFanOutRule(7*24*time.Hour, 20, 0.8)
+
+	if rule.Name() != "fan_out_rule" {
+		t.Errorf("Expected name 'fan_out_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestFanOutRule_Evaluate_NoCalls(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewFanOutRule(7*24*time.Hour, 20, 0.8)
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 for no calls, got %f", score.Score)
+	}
+}
+
+func TestFanOutRule_Evaluate_ReciprocalCaller(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewFanOutRule(7*24*time.Hour, 20, 0.8)
+	ctx := context.Background()
+	now := time.Now().Add(-time.Hour)
+
+	// Calls three numbers: one called back, one saved the caller, one did neither
+	addCall(t, graphRepo, "7379037972", "9876543210", now)
+	addCall(t, graphRepo, "9876543210", "7379037972", now)
+	addCall(t, graphRepo, "7379037972", "9876543211", now)
+	graphRepo.AddEdgeWithMetadata(ctx, "9876543211", "7379037972", &models.ContactMetadata{Name: "Friend", AddedAt: now})
+	addCall(t, graphRepo, "7379037972", "9876543212", now)
+
+	score, err := rule.Evaluate(ctx, "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 0.8 * (1 - 2/3) * (3/20)
+	if score.Score < 0.039 || score.Score > 0.041 {
+		t.Errorf("Expected score 0.04 for a small reciprocal call graph, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestFanOutRule_Evaluate_OneDirectionalFanOut(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewFanOutRule(7*24*time.Hour, 20, 0.8)
+	ctx := context.Background()
+	now := time.Now().Add(-time.Hour)
+
+	for i := 0; i < 40; i++ {
+		addCall(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), now)
+	}
+
+	// Calls outside the window are ignored
+	addCall(t, graphRepo, "14022000001", "5555555555", now.Add(-30*24*time.Hour))
+
+	score, err := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.8 {
+		t.Errorf("Expected maxScore 0.8 for a large one-directional call graph, got %f (%s)", score.Score, score.Reason)
+	}
+}
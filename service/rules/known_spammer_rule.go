@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// KnownSpammerRule evaluates spam score based on how much a caller's call neighbourhood
+// overlaps with that of numbers labeled as confirmed spam
+// A new SIM from an existing spam operation dials the same victim lists as the operation's
+// labeled numbers, and victims calling back reach both, so their neighbourhoods overlap
+// even though the new number has no reports yet
+type KnownSpammerRule struct {
+	timeWindow     time.Duration // Time window to analyze (e.g., 30 days)
+	minShared      int           // Shared neighbours required before an overlap counts
+	matchThreshold float64       // Weighted Jaccard overlap at which the score is maximal (e.g., 0.5)
+	maxScore       float64       // Maximum spam score for a full neighbourhood match
+}
+
+// NewKnownSpammerRule creates a new known-spammer neighbourhood rule
+func NewKnownSpammerRule(timeWindow time.Duration, minShared int, matchThreshold float64, maxScore float64) service.SpamRule {
+	return &KnownSpammerRule{
+		timeWindow:     timeWindow,
+		minShared:      minShared,
+		matchThreshold: matchThreshold,
+		maxScore:       maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *KnownSpammerRule) Name() string {
+	return "known_spammer_rule"
+}
+
+// Evaluate evaluates the known-spammer neighbourhood rule
+func (r *KnownSpammerRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// A labeled number is confirmed spam
+	if graphRepo.IsLabeledSpam(ctx, phoneNumber) {
+		return &models.SpamScore{
+			RuleName: r.Name(),
+			Score:    1.0,
+			Reason:   "Number is labeled as confirmed spam",
+		}, nil
+	}
+
+	timeStart := time.Now().Add(-r.timeWindow)
+	filters := repository.CallFilters{
+		TimeRangeStart: &timeStart,
+	}
+
+	// Query: The caller's neighbourhood (who it called, who called it back)
+	outgoing, _ := graphRepo.GetCallsWithFilters(ctx, phoneNumber, filters, "outgoing")
+	incoming, _ := graphRepo.GetCallsWithFilters(ctx, phoneNumber, filters, "incoming")
+	neighbourhood := callNeighbourhood(phoneNumber, outgoing, incoming)
+
+	if len(neighbourhood) == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("No calls in last %v", r.timeWindow),
+			NotApplicable: true,
+		}, nil
+	}
+
+	// Query: Labeled spammers one hop from the caller's neighbours
+	// (other callers of the same callees, other callees of the same callback numbers)
+	candidates := make(map[string]bool)
+	labeled := make(map[string]bool)
+	consider := func(number string) {
+		if number == phoneNumber || candidates[number] {
+			return
+		}
+		if _, checked := labeled[number]; !checked {
+			labeled[number] = graphRepo.IsLabeledSpam(ctx, number)
+		}
+		if labeled[number] {
+			candidates[number] = true
+		}
+	}
+
+	for key := range neighbourhood {
+		if key.outgoing {
+			calls, _ := graphRepo.GetCallsWithFilters(ctx, key.number, filters, "incoming")
+			for _, call := range calls {
+				consider(call.From)
+			}
+		} else {
+			calls, _ := graphRepo.GetCallsWithFilters(ctx, key.number, filters, "outgoing")
+			for _, call := range calls {
+				consider(call.To)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("No labeled spam numbers near the call neighbourhood of %d number(s)", len(neighbourhood)),
+			NotApplicable: true,
+		}, nil
+	}
+
+	// Compare with each candidate's neighbourhood
+	var bestOverlap float64
+	var bestSpammer string
+	var bestShared int
+	for spammer := range candidates {
+		spammerOut, _ := graphRepo.GetCallsWithFilters(ctx, spammer, filters, "outgoing")
+		spammerIn, _ := graphRepo.GetCallsWithFilters(ctx, spammer, filters, "incoming")
+
+		overlap, shared := weightedJaccard(neighbourhood, callNeighbourhood(spammer, spammerOut, spammerIn))
+		if shared < r.minShared {
+			continue
+		}
+		if overlap > bestOverlap || (overlap == bestOverlap && spammer < bestSpammer) {
+			bestOverlap, bestSpammer, bestShared = overlap, spammer, shared
+		}
+	}
+
+	if bestSpammer == "" {
+		return &models.SpamScore{
+			RuleName: r.Name(),
+			Score:    0.0,
+			Reason:   fmt.Sprintf("Call neighbourhood of %d number(s) does not overlap with labeled spam numbers", len(neighbourhood)),
+		}, nil
+	}
+
+	score := r.maxScore * math.Min(1.0, bestOverlap/r.matchThreshold)
+	reason := fmt.Sprintf("Call neighbourhood overlaps %.0f%% with labeled spam number %s (%d shared number(s) in last %v)",
+		bestOverlap*100, bestSpammer, bestShared, r.timeWindow)
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// neighbourKey identifies a neighbour and the direction of the calls to it:
+// a callee (outgoing) is a different relation than a callback number (incoming)
+type neighbourKey struct {
+	number   string
+	outgoing bool
+}
+
+// callNeighbourhood returns a number's call neighbours weighted by call count
+func callNeighbourhood(phoneNumber string, outgoing, incoming []*models.Edge) map[neighbourKey]float64 {
+	neighbourhood := make(map[neighbourKey]float64)
+	for _, call := range outgoing {
+		if call.To != phoneNumber {
+			neighbourhood[neighbourKey{number: call.To, outgoing: true}]++
+		}
+	}
+	for _, call := range incoming {
+		if call.From != phoneNumber {
+			neighbourhood[neighbourKey{number: call.From, outgoing: false}]++
+		}
+	}
+	return neighbourhood
+}
+
+// weightedJaccard returns sum(min)/sum(max) over two weighted sets, and the number of shared keys
+func weightedJaccard(a, b map[neighbourKey]float64) (float64, int) {
+	var minSum, maxSum float64
+	shared := 0
+
+	for key, wa := range a {
+		wb := b[key]
+		if wb > 0 {
+			shared++
+		}
+		minSum += math.Min(wa, wb)
+		maxSum += math.Max(wa, wb)
+	}
+	for key, wb := range b {
+		if _, ok := a[key]; !ok {
+			maxSum += wb
+		}
+	}
+
+	if maxSum == 0 {
+		return 0, 0
+	}
+	return minSum / maxSum, shared
+}
+
+// Ensure KnownSpammerRule implements SpamRule interface
+var _ service.SpamRule = (*KnownSpammerRule)(nil)
@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/repository"
+)
+
+func newTestKnownSpammerRule() *KnownSpammerRule {
+	return NewKnownSpammerRule(30*24*time.Hour, 2, 0.5, 0.9).(*KnownSpammerRule)
+}
+
+func TestKnownSpammerRule_Name(t *testing.T) {
+	rule := newTestKnownSpammerRule()
+
+	if rule.Name() != "known_spammer_rule" {
+		t.Errorf("Expected name 'known_spammer_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestKnownSpammerRule_Evaluate_LabeledNumber(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	graphRepo.LabelSpamNumber(context.Background(), "14022000001", "")
+
+	score, err := newTestKnownSpammerRule().Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 1.0 {
+		t.Errorf("Expected score 1.0 for a labeled number, got %f", score.Score)
+	}
+}
+
+func TestKnownSpammerRule_Evaluate_NewSIMFromSameOperation(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestKnownSpammerRule()
+	now := time.Now().Add(-time.Hour)
+
+	// The labeled number dialed a victim list of 10; one victim called back
+	graphRepo.LabelSpamNumber(context.Background(), "14022000001", "")
+	for i := 0; i < 10; i++ {
+		addCall(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), now)
+	}
+	addCall(t, graphRepo, "9876543200", "14022000001", now)
+
+	// The new SIM works through the same list and gets the same callback
+	for i := 2; i < 10; i++ {
+		addCall(t, graphRepo, "14022000099", fmt.Sprintf("98765432%02d", i), now)
+	}
+	addCall(t, graphRepo, "9876543200", "14022000099", now)
+
+	score, err := rule.Evaluate(context.Background(), "14022000099", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Overlap: 9 shared of 11 neighbours, above the match threshold
+	if score.Score < 0.899 {
+		t.Errorf("Expected score 0.9, got %f (%s)", score.Score, score.Reason)
+	}
+	if !strings.Contains(score.Reason, "14022000001") || !strings.Contains(score.Reason, "9 shared") {
+		t.Errorf("Expected reason to name the labeled number and shared count, got '%s'", score.Reason)
+	}
+}
+
+func TestKnownSpammerRule_Evaluate_PartialOverlap(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestKnownSpammerRule()
+	now := time.Now().Add(-time.Hour)
+
+	graphRepo.LabelSpamNumber(context.Background(), "14022000001", "")
+	for i := 0; i < 8; i++ {
+		addCall(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), now)
+	}
+
+	// Two callees in common out of 8 distinct: overlap 0.25, half the match threshold
+	addCall(t, graphRepo, "7379037972", "9876543200", now)
+	addCall(t, graphRepo, "7379037972", "9876543201", now)
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score < 0.449 || score.Score > 0.451 {
+		t.Errorf("Expected score 0.45, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestKnownSpammerRule_Evaluate_NoSharedNeighbours(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestKnownSpammerRule()
+	now := time.Now().Add(-time.Hour)
+
+	graphRepo.LabelSpamNumber(context.Background(), "14022000001", "")
+	addCall(t, graphRepo, "14022000001", "9876543200", now)
+	addCall(t, graphRepo, "14022000001", "9876543201", now)
+
+	// One shared callee is below the minimum, and an unlabeled caller of the same number is ignored
+	addCall(t, graphRepo, "7379037972", "9876543200", now)
+	addCall(t, graphRepo, "7379037972", "5555555555", now)
+	addCall(t, graphRepo, "1234567890", "5555555555", now)
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 || score.NotApplicable {
+		t.Errorf("Expected applicable score 0.0, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestKnownSpammerRule_Evaluate_NoLabeledNeighbours(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestKnownSpammerRule()
+	now := time.Now().Add(-time.Hour)
+
+	addCall(t, graphRepo, "7379037972", "9876543200", now)
+	addCall(t, graphRepo, "1234567890", "9876543200", now)
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 || !score.NotApplicable {
+		t.Errorf("Expected not applicable score 0.0 without labeled spammers nearby, got %f (not applicable: %v)", score.Score, score.NotApplicable)
+	}
+}
+
+func TestWeightedJaccard(t *testing.T) {
+	a := map[neighbourKey]float64{
+		{number: "1", outgoing: true}: 3,
+		{number: "2", outgoing: true}: 1,
+	}
+	b := map[neighbourKey]float64{
+		{number: "1", outgoing: true}:  1,
+		{number: "2", outgoing: false}: 1,
+	}
+
+	// min: 1; max: 3 + 1 + 1
+	overlap, shared := weightedJaccard(a, b)
+	if shared != 1 || overlap < 0.199 || overlap > 0.201 {
+		t.Errorf("Expected overlap 0.2 with 1 shared, got %f with %d", overlap, shared)
+	}
+}
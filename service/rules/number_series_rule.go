@@ -0,0 +1,108 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// NumberSeriesRule evaluates spam score from the number series (contiguous block) a number belongs to
+// Spam operations rent blocks like 14022xxxxx, so a number with little history of its own
+// inherits risk from its series: many spam reports or heavy call volume with few contact saves
+type NumberSeriesRule struct {
+	prefixLength  int     // Digits that identify a series (e.g., 5 for 14022xxxxxx)
+	minHistory    int     // Own edges (saves, calls, reports) at which the series is no longer consulted
+	minSeriesSize int     // Other numbers needed in the series before it is judged
+	maxScore      float64 // Maximum spam score inherited from a risky series
+}
+
+// NewNumberSeriesRule creates a new number series rule
+func NewNumberSeriesRule(prefixLength, minHistory, minSeriesSize int, maxScore float64) service.SpamRule {
+	return &NumberSeriesRule{
+		prefixLength:  prefixLength,
+		minHistory:    minHistory,
+		minSeriesSize: minSeriesSize,
+		maxScore:      maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *NumberSeriesRule) Name() string {
+	return "number_series_rule"
+}
+
+// Evaluate evaluates the number series rule
+func (r *NumberSeriesRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	if len(phoneNumber) <= r.prefixLength {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        "Phone number too short to belong to a number series",
+			NotApplicable: true,
+		}, nil
+	}
+
+	// The number's own history (maintained degrees, O(1))
+	ownReports := graphRepo.GetDegree(ctx, phoneNumber, models.EdgeTypeSpamReport, repository.DirectionIncoming)
+	ownSaves := graphRepo.GetDegree(ctx, phoneNumber, models.EdgeTypeContact, repository.DirectionIncoming)
+	ownCalls := graphRepo.GetDegree(ctx, phoneNumber, models.EdgeTypeCall, repository.DirectionBoth)
+	ownHistory := ownReports + ownSaves + ownCalls
+
+	if ownHistory >= r.minHistory {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("Phone number has its own history (%d edges), number series not consulted", ownHistory),
+			NotApplicable: true,
+		}, nil
+	}
+
+	// Query: Aggregates over the series, excluding the number itself
+	prefix := phoneNumber[:r.prefixLength]
+	stats := graphRepo.GetSeriesStats(ctx, prefix)
+
+	others := stats.NumberCount
+	if graphRepo.NodeExists(ctx, phoneNumber) {
+		others--
+	}
+
+	if others < r.minSeriesSize {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("Number series %s has only %d other known number(s)", prefix, others),
+			NotApplicable: true,
+		}, nil
+	}
+
+	n := float64(others)
+	reportsPerNumber := float64(stats.SpamReports-ownReports) / n
+	savesPerNumber := float64(stats.ContactSaves-ownSaves) / n
+	callsPerNumber := float64(stats.CallVolume-ownCalls) / n
+
+	// Series risk: reports or heavy call volume, discounted by how often its numbers are saved
+	spamSignal := math.Min(1.0, reportsPerNumber)
+	volumeSignal := math.Min(1.0, callsPerNumber/50.0)
+	trust := math.Min(1.0, savesPerNumber/3.0)
+	risk := (1.0 - trust) * math.Max(spamSignal, 0.5*volumeSignal)
+
+	// The less history of its own, the more the number inherits
+	inheritance := 1.0 - float64(ownHistory)/float64(r.minHistory)
+	score := r.maxScore * risk * inheritance
+
+	reason := fmt.Sprintf("Number series %s (%d other numbers): %.2f spam reports, %.2f contact saves and %.1f calls per number; own history %d edges",
+		prefix, others, reportsPerNumber, savesPerNumber, callsPerNumber, ownHistory)
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// Ensure NumberSeriesRule implements SpamRule interface
+var _ service.SpamRule = (*NumberSeriesRule)(nil)
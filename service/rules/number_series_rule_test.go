@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+func TestNumberSeriesRule_Name(t *testing.T) {
+	rule := NewNumberSeriesRule(5, 5, 3, 0.6)
+
+	if rule.Name() != "number_series_rule" {
+		t.Errorf("Expected name 'number_series_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestNumberSeriesRule_Evaluate_InheritsFromSpamSeries(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewNumberSeriesRule(5, 5, 3, 0.6)
+	ctx := context.Background()
+
+	// Every other number in 14022xxxxxx has been reported at least once
+	for i := 1; i <= 5; i++ {
+		number := fmt.Sprintf("1402200000%d", i)
+		addReport(t, graphRepo, "7379037972", number, time.Now())
+		addReport(t, graphRepo, "9876543210", number, time.Now())
+	}
+
+	// A fresh number from the same block with no history
+	score, err := rule.Evaluate(ctx, "14022000009", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.6 {
+		t.Errorf("Expected maxScore 0.6 for a fresh number in a spam series, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestNumberSeriesRule_Evaluate_TrustedSeries(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewNumberSeriesRule(5, 5, 3, 0.6)
+	ctx := context.Background()
+
+	// A block of numbers that users save, e.g. a bank's branches
+	for i := 1; i <= 5; i++ {
+		number := fmt.Sprintf("1800100000%d", i)
+		for j := 0; j < 3; j++ {
+			graphRepo.AddEdgeWithMetadata(ctx, fmt.Sprintf("98765432%02d", j), number, &models.ContactMetadata{Name: "Bank", AddedAt: time.Now()})
+		}
+	}
+
+	score, err := rule.Evaluate(ctx, "18001000009", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 for a trusted series, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestNumberSeriesRule_Evaluate_OwnHistory(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewNumberSeriesRule(5, 5, 3, 0.6)
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		addReport(t, graphRepo, "7379037972", fmt.Sprintf("1402200000%d", i), time.Now())
+	}
+
+	// A number with enough history of its own does not inherit
+	for j := 0; j < 5; j++ {
+		graphRepo.AddEdgeWithMetadata(ctx, fmt.Sprintf("98765432%02d", j), "14022000009", &models.ContactMetadata{Name: "Plumber", AddedAt: time.Now()})
+	}
+
+	score, err := rule.Evaluate(ctx, "14022000009", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 for a number with its own history, got %f", score.Score)
+	}
+
+	// Too small a series is not judged
+	score, err = rule.Evaluate(ctx, "55555000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 for an unknown series, got %f", score.Score)
+	}
+}
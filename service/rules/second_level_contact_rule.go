@@ -35,9 +35,10 @@ func (r *SecondLevelContactRule) Evaluate(ctx context.Context, phoneNumber strin
 	// If user phone number is not provided, skip this rule
 	if userPhoneNumber == "" {
 		return &models.SpamScore{
-			RuleName: r.Name(),
-			Score:    0.0,
-			Reason:   "User phone number not provided, skipping second-level contact check",
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        "User phone number not provided, skipping second-level contact check",
+			NotApplicable: true,
 		}, nil
 	}
 
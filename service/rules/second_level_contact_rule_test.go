@@ -47,6 +47,10 @@ func TestSecondLevelContactRule_Evaluate_NoUserPhone(t *testing.T) {
 	if score.Score != 0.0 {
 		t.Errorf("Expected score 0.0 when user phone not provided, got %f", score.Score)
 	}
+
+	if !score.NotApplicable {
+		t.Error("Expected the rule to be marked not applicable when user phone not provided")
+	}
 }
 
 func TestSecondLevelContactRule_Evaluate_DirectContact(t *testing.T) {
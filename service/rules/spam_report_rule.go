@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// SpamReportRule evaluates spam score from the spam reports filed against a number
+// Each report is weighted by the reporter's trust and decays with age, so a burst of
+// reports from fresh, unknown accounts (brigading) counts for little
+//
+// Reporter trust is the product of:
+//   - account age: time since the reporter's first activity in the graph, relative to matureAge
+//   - contact count: users who saved the reporter, relative to trustedContacts
+//   - consensus agreement: how often the reporter's past reports were backed by
+//     other trusted reporters (a reporter with no history is not penalized)
+type SpamReportRule struct {
+	maxScore        float64       // Maximum spam score for heavily reported numbers
+	halfLife        time.Duration // Time after which a report counts half as much
+	matureAge       time.Duration // Account age at which a reporter is fully trusted on age
+	trustedContacts int           // Users saving the reporter at which they are fully trusted on contacts
+	consensusTrust  float64       // Trust from other reporters needed for a past report to match consensus
+}
+
+// NewSpamReportRule creates a new spam report rule
+func NewSpamReportRule(maxScore float64, halfLife, matureAge time.Duration, trustedContacts int, consensusTrust float64) service.SpamRule {
+	return &SpamReportRule{
+		maxScore:        maxScore,
+		halfLife:        halfLife,
+		matureAge:       matureAge,
+		trustedContacts: trustedContacts,
+		consensusTrust:  consensusTrust,
+	}
+}
+
+// Name returns the rule name
+func (r *SpamReportRule) Name() string {
+	return "spam_report_rule"
+}
+
+// Evaluate evaluates the spam report rule
+func (r *SpamReportRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// Query: Who has reported this phone number?
+	reports := graphRepo.GetIncomingEdges(ctx, phoneNumber, models.EdgeTypeSpamReport)
+
+	if len(reports) == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        "Phone number not reported as spam by any user",
+			NotApplicable: true,
+		}, nil
+	}
+
+	now := time.Now()
+	baseTrust := make(map[string]float64)
+
+	var weight float64
+	untrusted := 0
+	for _, report := range reports {
+		trust := r.reporterTrust(ctx, report.From, phoneNumber, now, graphRepo, baseTrust)
+		if trust < 0.1 {
+			untrusted++
+		}
+		weight += trust * r.decay(now.Sub(report.CreatedAt))
+	}
+
+	// Score increases with the weighted report total but caps at maxScore
+	score := r.maxScore * (1.0 - 1.0/(1.0+weight))
+
+	reason := fmt.Sprintf("Reported as spam by %d user(s) with trust-weighted total %.2f (%d from new or untrusted accounts)",
+		len(reports), weight, untrusted)
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// reporterTrust returns the trust of a reporter between 0.0 and 1.0
+// The report against phoneNumber itself is left out of the consensus agreement
+func (r *SpamReportRule) reporterTrust(ctx context.Context, reporter, phoneNumber string, now time.Time, graphRepo repository.GraphRepository, baseTrust map[string]float64) float64 {
+	base := r.baseTrust(ctx, reporter, now, graphRepo, baseTrust)
+	if base == 0 {
+		return 0
+	}
+
+	// Consensus: were the reporter's other reports backed by other trusted reporters?
+	past, matched := 0, 0
+	for _, report := range graphRepo.GetOutgoingEdges(ctx, reporter, models.EdgeTypeSpamReport) {
+		if report.To == phoneNumber {
+			continue
+		}
+		past++
+
+		var support float64
+		for _, other := range graphRepo.GetIncomingEdges(ctx, report.To, models.EdgeTypeSpamReport) {
+			if other.From != reporter {
+				support += r.baseTrust(ctx, other.From, now, graphRepo, baseTrust)
+			}
+		}
+		if support >= r.consensusTrust {
+			matched++
+		}
+	}
+
+	// Smoothed agreement is 0.5 with no history, which keeps the full base trust;
+	// a reporter whose reports are mostly unsupported loses trust
+	agreement := float64(matched+1) / float64(past+2)
+	return base * math.Min(1.0, agreement*2)
+}
+
+// baseTrust returns the trust of a reporter from account age and contact count alone,
+// memoized in cache since the same reporters recur in consensus checks
+func (r *SpamReportRule) baseTrust(ctx context.Context, reporter string, now time.Time, graphRepo repository.GraphRepository, cache map[string]float64) float64 {
+	if trust, ok := cache[reporter]; ok {
+		return trust
+	}
+
+	// A zero matureAge or trustedContacts disables that factor
+	var ageFactor float64
+	if firstSeen := firstActivity(ctx, reporter, graphRepo); !firstSeen.IsZero() {
+		ageFactor = 1.0
+		if r.matureAge > 0 {
+			ageFactor = math.Min(1.0, float64(now.Sub(firstSeen))/float64(r.matureAge))
+		}
+	}
+
+	contactFactor := 1.0
+	if r.trustedContacts > 0 {
+		savedBy := graphRepo.GetDegree(ctx, reporter, models.EdgeTypeContact, repository.DirectionIncoming)
+		contactFactor = math.Min(1.0, float64(savedBy)/float64(r.trustedContacts))
+	}
+
+	trust := ageFactor * contactFactor
+	cache[reporter] = trust
+	return trust
+}
+
+// decay returns the weight of a report of the given age
+func (r *SpamReportRule) decay(age time.Duration) float64 {
+	// A zero halfLife disables decay
+	if age <= 0 || r.halfLife <= 0 {
+		return 1.0
+	}
+	return math.Pow(0.5, float64(age)/float64(r.halfLife))
+}
+
+// firstActivity returns the earliest time a number saved a contact, called or reported a number
+// The graph has no account creation time, so this stands in for account age
+func firstActivity(ctx context.Context, phoneNumber string, graphRepo repository.GraphRepository) time.Time {
+	var first time.Time
+
+	edges := graphRepo.GetOutgoingEdges(ctx, phoneNumber, models.EdgeTypeContact)
+	edges = append(edges, graphRepo.GetOutgoingEdges(ctx, phoneNumber, models.EdgeTypeCall)...)
+	edges = append(edges, graphRepo.GetOutgoingEdges(ctx, phoneNumber, models.EdgeTypeSpamReport)...)
+
+	for _, edge := range edges {
+		if edge.CreatedAt.IsZero() {
+			continue
+		}
+		if first.IsZero() || edge.CreatedAt.Before(first) {
+			first = edge.CreatedAt
+		}
+	}
+
+	return first
+}
+
+// Ensure SpamReportRule implements SpamRule interface
+var _ service.SpamRule = (*SpamReportRule)(nil)
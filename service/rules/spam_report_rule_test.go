@@ -0,0 +1,186 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+const (
+	testHalfLife  = 30 * 24 * time.Hour
+	testMatureAge = 90 * 24 * time.Hour
+)
+
+func newTestSpamReportRule() *SpamReportRule {
+	return NewSpamReportRule(0.9, testHalfLife, testMatureAge, 3, 1.0).(*SpamReportRule)
+}
+
+// addTrustedReporter makes a reporter that has been active for a year and is saved by 3 users
+func addTrustedReporter(t *testing.T, graphRepo repository.GraphRepository, reporter string) {
+	ctx := context.Background()
+	yearAgo := time.Now().Add(-365 * 24 * time.Hour)
+
+	if _, err := graphRepo.AddEdgeWithMetadata(ctx, reporter, "1000000000", &models.ContactMetadata{Name: "Mom", AddedAt: yearAgo}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		saver := fmt.Sprintf("200000000%d", i)
+		if _, err := graphRepo.AddEdgeWithMetadata(ctx, saver, reporter, &models.ContactMetadata{Name: "Friend", AddedAt: yearAgo}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+// addReport files a spam report at the given time
+func addReport(t *testing.T, graphRepo repository.GraphRepository, reporter, phoneNumber string, reportedAt time.Time) {
+	_, err := graphRepo.AddEdgeWithMetadata(context.Background(), reporter, phoneNumber, &models.SpamReportMetadata{
+		Category:   models.SpamCategoryTelemarketing,
+		ReportedAt: reportedAt,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func evaluateReports(t *testing.T, rule *SpamReportRule, graphRepo repository.GraphRepository, phoneNumber string) float64 {
+	score, err := rule.Evaluate(context.Background(), phoneNumber, "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return score.Score
+}
+
+func TestSpamReportRule_Name(t *testing.T) {
+	rule := newTestSpamReportRule()
+
+	if rule.Name() != "spam_report_rule" {
+		t.Errorf("Expected name 'spam_report_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestSpamReportRule_Evaluate_NoReports(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestSpamReportRule()
+
+	score, err := rule.Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 || !score.NotApplicable {
+		t.Errorf("Expected not applicable score 0.0 for no reports, got %f (not applicable: %v)", score.Score, score.NotApplicable)
+	}
+}
+
+func TestSpamReportRule_Evaluate_TrustedReporters(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestSpamReportRule()
+
+	for _, reporter := range []string{"7379037972", "9876543210"} {
+		addTrustedReporter(t, graphRepo, reporter)
+		addReport(t, graphRepo, reporter, "14022000001", time.Now())
+	}
+
+	// Two fully trusted fresh reports: 0.9 * (1 - 1/3)
+	score := evaluateReports(t, rule, graphRepo, "14022000001")
+	if score < 0.59 || score > 0.61 {
+		t.Errorf("Expected score ~0.6 for two trusted reports, got %f", score)
+	}
+}
+
+func TestSpamReportRule_Evaluate_ZeroTrustSettings(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewSpamReportRule(0.9, 0, 0, 0, 1.0).(*SpamReportRule)
+
+	// A reporter nobody saved, reporting a month ago
+	addReport(t, graphRepo, "7379037972", "14022000001", time.Now().Add(-testHalfLife))
+
+	// With the factors disabled the reporter is fully trusted and the report does not decay
+	score := evaluateReports(t, rule, graphRepo, "14022000001")
+	if math.IsNaN(score) || score <= 0.0 || score > 0.9 {
+		t.Errorf("Expected a finite score in (0, 0.9] with zero settings, got %f", score)
+	}
+}
+
+func TestSpamReportRule_Evaluate_ResistsBrigading(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestSpamReportRule()
+
+	// Ten fresh accounts with no contacts mass-report a business,
+	// and back each other up on a second target
+	for i := 0; i < 10; i++ {
+		reporter := fmt.Sprintf("555000000%d", i)
+		addReport(t, graphRepo, reporter, "18005550100", time.Now())
+		addReport(t, graphRepo, reporter, "18005550199", time.Now())
+	}
+
+	brigaded := evaluateReports(t, rule, graphRepo, "18005550100")
+
+	// A single trusted reporter outweighs the whole brigade
+	addTrustedReporter(t, graphRepo, "7379037972")
+	addReport(t, graphRepo, "7379037972", "14022000001", time.Now())
+
+	reported := evaluateReports(t, rule, graphRepo, "14022000001")
+
+	if brigaded >= 0.05 {
+		t.Errorf("Expected brigaded score below 0.05, got %f", brigaded)
+	}
+	if reported <= brigaded {
+		t.Errorf("Expected one trusted report (%f) to outweigh the brigade (%f)", reported, brigaded)
+	}
+}
+
+func TestSpamReportRule_Evaluate_Decay(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestSpamReportRule()
+
+	addTrustedReporter(t, graphRepo, "7379037972")
+	addTrustedReporter(t, graphRepo, "9876543210")
+	addReport(t, graphRepo, "7379037972", "14022000001", time.Now())
+	addReport(t, graphRepo, "9876543210", "14022000002", time.Now().Add(-2*testHalfLife))
+
+	fresh := evaluateReports(t, rule, graphRepo, "14022000001")
+	old := evaluateReports(t, rule, graphRepo, "14022000002")
+
+	// Two half-lives leave a quarter of the weight: 0.9 * (1 - 1/1.25)
+	if old < 0.17 || old > 0.19 {
+		t.Errorf("Expected decayed score ~0.18, got %f", old)
+	}
+	if old >= fresh {
+		t.Errorf("Expected old report (%f) to score below fresh report (%f)", old, fresh)
+	}
+}
+
+func TestSpamReportRule_Evaluate_ConsensusAgreement(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestSpamReportRule()
+
+	// The agreeing reporter's past reports are backed by another trusted reporter
+	addTrustedReporter(t, graphRepo, "7379037972")
+	addTrustedReporter(t, graphRepo, "9876543210")
+	for _, target := range []string{"14022000011", "14022000012", "14022000013"} {
+		addReport(t, graphRepo, "7379037972", target, time.Now())
+		addReport(t, graphRepo, "9876543210", target, time.Now())
+	}
+	addReport(t, graphRepo, "7379037972", "14022000001", time.Now())
+
+	// The contrarian's past reports were backed by no one
+	graphRepo2 := repository.NewInMemoryGraphRepository()
+	addTrustedReporter(t, graphRepo2, "7379037972")
+	for _, target := range []string{"14022000011", "14022000012", "14022000013"} {
+		addReport(t, graphRepo2, "7379037972", target, time.Now())
+	}
+	addReport(t, graphRepo2, "7379037972", "14022000001", time.Now())
+
+	agreeing := evaluateReports(t, rule, graphRepo, "14022000001")
+	contrarian := evaluateReports(t, rule, graphRepo2, "14022000001")
+
+	if contrarian >= agreeing {
+		t.Errorf("Expected contrarian reporter (%f) to score below agreeing reporter (%f)", contrarian, agreeing)
+	}
+}
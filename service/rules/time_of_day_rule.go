@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// Calling hours in the caller's local time
+const (
+	businessHoursStart = 9  // 09:00
+	businessHoursEnd   = 18 // 18:00
+	nightHoursStart    = 22 // 22:00
+	nightHoursEnd      = 6  // 06:00
+)
+
+// burstGap is the longest gap between two calls that still belong to the same burst
+const burstGap = time.Hour
+
+// TimeOfDayRule evaluates spam score based on when a number makes its calls
+// Builds an hourly histogram of outgoing calls in the caller's local time and flags
+// traffic at unusual night hours, and business-hour bursts with machine-like regularity
+// (near-constant gaps between calls, as an autodialer produces)
+type TimeOfDayRule struct {
+	timeWindow      time.Duration             // Time window to analyze (e.g., 7 days)
+	minCallCount    int                       // Minimum outgoing calls before the pattern is judged
+	timezones       map[string]*time.Location // Local timezone by number prefix (longest prefix wins)
+	defaultTimezone *time.Location            // Timezone for numbers matching no prefix
+	maxScore        float64                   // Maximum spam score
+}
+
+// NewTimeOfDayRule creates a new time-of-day rule
+func NewTimeOfDayRule(timeWindow time.Duration, minCallCount int, timezones map[string]*time.Location, defaultTimezone *time.Location, maxScore float64) service.SpamRule {
+	if defaultTimezone == nil {
+		defaultTimezone = time.UTC
+	}
+
+	return &TimeOfDayRule{
+		timeWindow:      timeWindow,
+		minCallCount:    minCallCount,
+		timezones:       timezones,
+		defaultTimezone: defaultTimezone,
+		maxScore:        maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *TimeOfDayRule) Name() string {
+	return "time_of_day_rule"
+}
+
+// Evaluate evaluates the time-of-day rule
+func (r *TimeOfDayRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	timeStart := time.Now().Add(-r.timeWindow)
+	minCallCount := r.minCallCount
+
+	// Query: Outgoing calls in the window, if there are enough to judge
+	calls, total := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+		TimeRangeStart: &timeStart,
+		MinCallCount:   &minCallCount,
+	}, "outgoing")
+
+	if total == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("Fewer than %d outgoing calls in last %v", r.minCallCount, r.timeWindow),
+			NotApplicable: true,
+		}, nil
+	}
+
+	location := r.timezoneFor(phoneNumber)
+
+	timestamps := make([]time.Time, 0, total)
+	for _, call := range calls {
+		timestamps = append(timestamps, call.CreatedAt.In(location))
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	// Hourly histogram in local time
+	var histogram [24]int
+	business, night := 0, 0
+	for _, ts := range timestamps {
+		hour := ts.Hour()
+		histogram[hour]++
+		if hour >= businessHoursStart && hour < businessHoursEnd {
+			business++
+		}
+		if hour >= nightHoursStart || hour < nightHoursEnd {
+			night++
+		}
+	}
+
+	businessShare := float64(business) / float64(total)
+	nightShare := float64(night) / float64(total)
+
+	// Regularity: a coefficient of variation near 0 means evenly spaced, machine-like calls
+	cv, hasBursts := burstGapVariation(timestamps)
+	var regularity float64
+	if hasBursts {
+		regularity = math.Max(0, 1.0-cv/0.5)
+	}
+
+	// Night calling and regular business-hour bursts are independent signals; take the stronger
+	burstSignal := businessShare * regularity
+	signal := math.Max(nightShare, burstSignal)
+
+	reason := fmt.Sprintf("Hourly histogram (%s): %s; %.0f%% in business hours, %.0f%% at night",
+		location, histogramSummary(histogram), businessShare*100, nightShare*100)
+	if hasBursts {
+		reason += fmt.Sprintf(", burst gap variation %.2f", cv)
+	}
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    r.maxScore * signal,
+		Reason:   reason,
+	}, nil
+}
+
+// timezoneFor returns the local timezone of a phone number by its longest matching prefix
+func (r *TimeOfDayRule) timezoneFor(phoneNumber string) *time.Location {
+	location := r.defaultTimezone
+	longest := 0
+	for prefix, loc := range r.timezones {
+		if len(prefix) > longest && strings.HasPrefix(phoneNumber, prefix) {
+			location, longest = loc, len(prefix)
+		}
+	}
+	return location
+}
+
+// burstGapVariation returns the coefficient of variation (stddev / mean) of the gaps
+// between consecutive calls that are part of a burst; false if there are too few gaps
+// Timestamps must be sorted
+func burstGapVariation(timestamps []time.Time) (float64, bool) {
+	gaps := make([]float64, 0)
+	for i := 1; i < len(timestamps); i++ {
+		if gap := timestamps[i].Sub(timestamps[i-1]); gap <= burstGap {
+			gaps = append(gaps, gap.Seconds())
+		}
+	}
+
+	if len(gaps) < 3 {
+		return 0, false
+	}
+
+	var mean float64
+	for _, gap := range gaps {
+		mean += gap
+	}
+	mean /= float64(len(gaps))
+	if mean == 0 {
+		// Simultaneous calls are as regular as it gets
+		return 0, true
+	}
+
+	var variance float64
+	for _, gap := range gaps {
+		variance += (gap - mean) * (gap - mean)
+	}
+	variance /= float64(len(gaps))
+
+	return math.Sqrt(variance) / mean, true
+}
+
+// histogramSummary renders the non-empty hours of a histogram, e.g. "10h:12 11h:8 (peak 10h)"
+func histogramSummary(histogram [24]int) string {
+	parts := make([]string, 0)
+	peak := 0
+	for hour, count := range histogram {
+		if count == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%02dh:%d", hour, count))
+		if count > histogram[peak] {
+			peak = hour
+		}
+	}
+	return fmt.Sprintf("%s (peak %02dh)", strings.Join(parts, " "), peak)
+}
+
+// Ensure TimeOfDayRule implements SpamRule interface
+var _ service.SpamRule = (*TimeOfDayRule)(nil)
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/repository"
+)
+
+var testIST = time.FixedZone("IST", 5*3600+1800)
+
+func newTestTimeOfDayRule() *TimeOfDayRule {
+	timezones := map[string]*time.Location{
+		"91":   testIST,
+		"9140": time.UTC,
+	}
+	return NewTimeOfDayRule(7*24*time.Hour, 10, timezones, time.UTC, 0.7).(*TimeOfDayRule)
+}
+
+// localTime returns the given local time two days ago
+func localTime(hour, minute int, loc *time.Location) time.Time {
+	day := time.Now().In(loc).AddDate(0, 0, -2)
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+}
+
+func TestTimeOfDayRule_Name(t *testing.T) {
+	rule := newTestTimeOfDayRule()
+
+	if rule.Name() != "time_of_day_rule" {
+		t.Errorf("Expected name 'time_of_day_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestTimeOfDayRule_Evaluate_MachineLikeBurst(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestTimeOfDayRule()
+
+	// An autodialer: 20 calls exactly 30 seconds apart from 10:00
+	base := localTime(10, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		addCall(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), base.Add(time.Duration(i*30)*time.Second))
+	}
+
+	score, err := rule.Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score < 0.699 {
+		t.Errorf("Expected score 0.7 for a regular business-hour burst, got %f (%s)", score.Score, score.Reason)
+	}
+
+	if !strings.Contains(score.Reason, "10h:20") {
+		t.Errorf("Expected reason to include the histogram, got '%s'", score.Reason)
+	}
+}
+
+func TestTimeOfDayRule_Evaluate_HumanCaller(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestTimeOfDayRule()
+
+	// Calls spread irregularly through the day
+	minutes := []int{0, 7, 95, 180, 200, 310, 420, 433, 500, 610, 700}
+	base := localTime(8, 0, time.UTC)
+	for i, minute := range minutes {
+		addCall(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), base.Add(time.Duration(minute)*time.Minute))
+	}
+
+	score, err := rule.Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score > 0.1 {
+		t.Errorf("Expected low score for a human caller, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestTimeOfDayRule_Evaluate_NightCallsInLocalTimezone(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestTimeOfDayRule()
+	ctx := context.Background()
+
+	// 02:00 in India is 20:30 UTC the previous evening
+	base := localTime(2, 0, testIST)
+	minutes := []int{0, 3, 11, 14, 30, 41, 42, 55, 70, 72}
+	for _, caller := range []string{"919876543210", "449876543210"} {
+		for i, minute := range minutes {
+			addCall(t, graphRepo, caller, fmt.Sprintf("98765432%02d", i), base.Add(time.Duration(minute)*time.Minute))
+		}
+	}
+
+	indian, err := rule.Evaluate(ctx, "919876543210", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if indian.Score < 0.699 {
+		t.Errorf("Expected score 0.7 for calls at 02:00 local time, got %f (%s)", indian.Score, indian.Reason)
+	}
+
+	// The same instants are evening calls for a number in UTC
+	other, err := rule.Evaluate(ctx, "449876543210", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if other.Score >= indian.Score {
+		t.Errorf("Expected evening calls (%f) to score below night calls (%f)", other.Score, indian.Score)
+	}
+}
+
+func TestTimeOfDayRule_TimezoneFor(t *testing.T) {
+	rule := newTestTimeOfDayRule()
+
+	if loc := rule.timezoneFor("919876543210"); loc != testIST {
+		t.Errorf("Expected IST for prefix 91, got %v", loc)
+	}
+	if loc := rule.timezoneFor("914012345678"); loc != time.UTC {
+		t.Errorf("Expected the longest prefix 9140 to win, got %v", loc)
+	}
+	if loc := rule.timezoneFor("14022000001"); loc != time.UTC {
+		t.Errorf("Expected the default timezone, got %v", loc)
+	}
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// TrustRankRule evaluates spam score from the node's global trust rank
+// The score is computed offline by service.TrustRankJob and stored on the node, so unlike
+// SecondLevelContactRule it works without a requesting user and looks beyond two hops
+type TrustRankRule struct {
+	threshold float64 // Trust rank at which a number is considered trusted (0.5 = average node)
+	maxScore  float64 // Maximum spam score for a number no trusted user reaches
+}
+
+// NewTrustRankRule creates a new trust rank rule
+func NewTrustRankRule(threshold float64, maxScore float64) service.SpamRule {
+	return &TrustRankRule{
+		threshold: threshold,
+		maxScore:  maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *TrustRankRule) Name() string {
+	return "trust_rank_rule"
+}
+
+// Evaluate evaluates the trust rank rule
+func (r *TrustRankRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	// Query: Stored trust rank of the node
+	value, ok := graphRepo.GetNodeProperty(ctx, phoneNumber, repository.NodePropertyTrustRank)
+	trust, isFloat := value.(float64)
+
+	if !ok || !isFloat {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        "Trust rank not computed for this number",
+			NotApplicable: true,
+		}, nil
+	}
+
+	// The walk never reaches a number nobody saved; ContactCountRule already scores that
+	if graphRepo.GetDegree(ctx, phoneNumber, models.EdgeTypeContact, repository.DirectionIncoming) == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        "Phone number not saved by any user, so trust rank cannot reach it",
+			NotApplicable: true,
+		}, nil
+	}
+
+	var score float64
+	var reason string
+
+	if trust >= r.threshold {
+		score = 0.0
+		reason = fmt.Sprintf("Trust rank %.3f (trusted, threshold %.2f)", trust, r.threshold)
+	} else {
+		// Score decreases as trust approaches the threshold
+		score = r.maxScore * (1.0 - trust/r.threshold)
+		reason = fmt.Sprintf("Trust rank %.3f (below threshold of %.2f)", trust, r.threshold)
+	}
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    score,
+		Reason:   reason,
+	}, nil
+}
+
+// Ensure TrustRankRule implements SpamRule interface
+var _ service.SpamRule = (*TrustRankRule)(nil)
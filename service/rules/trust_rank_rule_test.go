@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+func TestTrustRankRule_Name(t *testing.T) {
+	rule := NewTrustRankRule(0.3, 0.6)
+
+	if rule.Name() != "trust_rank_rule" {
+		t.Errorf("Expected name 'trust_rank_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestTrustRankRule_Evaluate(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := NewTrustRankRule(0.3, 0.6)
+	ctx := context.Background()
+
+	// Not computed yet
+	graphRepo.AddNode(ctx, "14022000001")
+	score, err := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 without a trust rank, got %f", score.Score)
+	}
+
+	// Nobody has saved the number yet, so its trust rank says nothing
+	graphRepo.SetNodeProperties(ctx, "14022000001", map[string]interface{}{
+		repository.NodePropertyTrustRank: 0.0,
+	})
+	score, err = rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if score.Score != 0.0 || !score.NotApplicable {
+		t.Errorf("Expected not applicable score 0.0 for a number nobody saved, got %f (not applicable: %v)", score.Score, score.NotApplicable)
+	}
+
+	// Saved only within a ring the walk does not reach
+	if _, err := graphRepo.AddEdgeWithMetadata(ctx, "14022000002", "14022000001", &models.ContactMetadata{Name: "Ring", AddedAt: time.Now()}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		trust    float64
+		expected float64
+	}{
+		{0.0, 0.6},
+		{0.15, 0.3},
+		{0.5, 0.0},
+	}
+
+	for _, tt := range tests {
+		graphRepo.SetNodeProperties(ctx, "14022000001", map[string]interface{}{
+			repository.NodePropertyTrustRank: tt.trust,
+		})
+
+		score, err := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if score.Score < tt.expected-0.001 || score.Score > tt.expected+0.001 {
+			t.Errorf("Expected score %f for trust %f, got %f", tt.expected, tt.trust, score.Score)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+	"credCode/service"
+)
+
+// UnansweredCallRule evaluates spam score based on unanswered outbound calls
+// Detects the "one ring and hang up" (wangiri) callback scam: many outgoing calls that
+// are dropped before they can be answered, hoping the callee calls back a premium number.
+// Complements CallPatternRule, which looks at answered-but-short calls
+type UnansweredCallRule struct {
+	timeWindow       time.Duration // Time window to analyze (e.g., 24 hours)
+	ringDropDuration int           // Maximum duration in seconds of a ring-and-drop call
+	minCallCount     int           // Minimum outgoing calls before the pattern is judged
+	ratioThreshold   float64       // Unanswered share above which the ratio counts as suspicious
+	riskyPrefixes    []string      // International or premium-rate prefixes of the caller
+	homePrefixes     []string      // Home country prefixes that are never risky (e.g., +91 under the + prefix)
+	maxScore         float64       // Maximum spam score
+}
+
+// NewUnansweredCallRule creates a new unanswered call rule
+func NewUnansweredCallRule(timeWindow time.Duration, ringDropDuration, minCallCount int, ratioThreshold float64, riskyPrefixes, homePrefixes []string, maxScore float64) service.SpamRule {
+	return &UnansweredCallRule{
+		timeWindow:       timeWindow,
+		ringDropDuration: ringDropDuration,
+		minCallCount:     minCallCount,
+		ratioThreshold:   ratioThreshold,
+		riskyPrefixes:    riskyPrefixes,
+		homePrefixes:     homePrefixes,
+		maxScore:         maxScore,
+	}
+}
+
+// Name returns the rule name
+func (r *UnansweredCallRule) Name() string {
+	return "unanswered_call_rule"
+}
+
+// Evaluate evaluates the unanswered call rule
+func (r *UnansweredCallRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	timeStart := time.Now().Add(-r.timeWindow)
+	minCallCount := r.minCallCount
+
+	// Query: All outgoing calls in the window, if there are enough to judge
+	_, total := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+		TimeRangeStart: &timeStart,
+		MinCallCount:   &minCallCount,
+	}, "outgoing")
+
+	if total == 0 {
+		return &models.SpamScore{
+			RuleName:      r.Name(),
+			Score:         0.0,
+			Reason:        fmt.Sprintf("Fewer than %d outgoing calls in last %v", r.minCallCount, r.timeWindow),
+			NotApplicable: true,
+		}, nil
+	}
+
+	// Filter: is_answered=false
+	answered := false
+	_, unanswered := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+		IsAnswered:     &answered,
+		TimeRangeStart: &timeStart,
+	}, "outgoing")
+
+	// Filter: is_answered=false AND duration<=ringDropDuration
+	maxDuration := r.ringDropDuration
+	_, ringDrops := graphRepo.GetCallsWithFilters(ctx, phoneNumber, repository.CallFilters{
+		IsAnswered:     &answered,
+		MaxDuration:    &maxDuration,
+		TimeRangeStart: &timeStart,
+	}, "outgoing")
+
+	ringDropShare := float64(ringDrops) / float64(total)
+	unansweredRatio := float64(unanswered) / float64(total)
+
+	// Ring-and-drop share dominates; the unanswered ratio only counts above its threshold
+	var ratioSignal float64
+	if unansweredRatio > r.ratioThreshold && r.ratioThreshold < 1.0 {
+		ratioSignal = (unansweredRatio - r.ratioThreshold) / (1.0 - r.ratioThreshold)
+	}
+	signal := 0.7*ringDropShare + 0.3*ratioSignal
+
+	reason := fmt.Sprintf("%d of %d outgoing calls unanswered, %d ring-and-drop (<=%ds) in last %v",
+		unanswered, total, ringDrops, r.ringDropDuration, r.timeWindow)
+
+	// Callbacks to international or premium numbers are what the scam monetises
+	if prefix := r.riskyPrefix(phoneNumber); prefix != "" && signal > 0 {
+		signal = math.Min(1.0, signal+0.25)
+		reason += fmt.Sprintf("; caller has international/premium prefix %s", prefix)
+	}
+
+	return &models.SpamScore{
+		RuleName: r.Name(),
+		Score:    r.maxScore * signal,
+		Reason:   reason,
+	}, nil
+}
+
+// riskyPrefix returns the international or premium prefix the phone number starts with, if any
+// Home country numbers have none, even in international format
+func (r *UnansweredCallRule) riskyPrefix(phoneNumber string) string {
+	for _, prefix := range r.homePrefixes {
+		if prefix != "" && strings.HasPrefix(phoneNumber, prefix) {
+			return ""
+		}
+	}
+
+	for _, prefix := range r.riskyPrefixes {
+		if prefix != "" && strings.HasPrefix(phoneNumber, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// Ensure UnansweredCallRule implements SpamRule interface
+var _ service.SpamRule = (*UnansweredCallRule)(nil)
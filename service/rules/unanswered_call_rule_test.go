@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+func newTestUnansweredCallRule() *UnansweredCallRule {
+	return NewUnansweredCallRule(24*time.Hour, 3, 5, 0.7, []string{"00", "+", "1900"}, []string{"+91", "0091"}, 0.8).(*UnansweredCallRule)
+}
+
+// addCallWithOutcome records a call with the given outcome an hour ago
+func addCallWithOutcome(t *testing.T, graphRepo repository.GraphRepository, from, to string, isAnswered bool, duration int) {
+	_, err := graphRepo.AddEdgeWithMetadata(context.Background(), from, to, &models.CallMetadata{
+		IsAnswered:        isAnswered,
+		DurationInSeconds: duration,
+		Timestamp:         time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestUnansweredCallRule_Name(t *testing.T) {
+	rule := newTestUnansweredCallRule()
+
+	if rule.Name() != "unanswered_call_rule" {
+		t.Errorf("Expected name 'unanswered_call_rule', got '%s'", rule.Name())
+	}
+}
+
+func TestUnansweredCallRule_Evaluate_TooFewCalls(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestUnansweredCallRule()
+
+	for i := 0; i < 4; i++ {
+		addCallWithOutcome(t, graphRepo, "14022000001", fmt.Sprintf("98765432%02d", i), false, 1)
+	}
+
+	score, err := rule.Evaluate(context.Background(), "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 below the minimum call count, got %f", score.Score)
+	}
+}
+
+func TestUnansweredCallRule_Evaluate_NormalCaller(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestUnansweredCallRule()
+
+	// Mostly answered calls, one missed call that rang for a while
+	for i := 0; i < 5; i++ {
+		addCallWithOutcome(t, graphRepo, "7379037972", fmt.Sprintf("98765432%02d", i), true, 120)
+	}
+	addCallWithOutcome(t, graphRepo, "7379037972", "9876543299", false, 25)
+
+	score, err := rule.Evaluate(context.Background(), "7379037972", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if score.Score != 0.0 {
+		t.Errorf("Expected score 0.0 for a normal caller, got %f (%s)", score.Score, score.Reason)
+	}
+}
+
+func TestUnansweredCallRule_Evaluate_Wangiri(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestUnansweredCallRule()
+	ctx := context.Background()
+
+	// Every call rings once and hangs up
+	for _, caller := range []string{"14022000001", "+88212345678"} {
+		for i := 0; i < 10; i++ {
+			addCallWithOutcome(t, graphRepo, caller, fmt.Sprintf("98765432%02d", i), false, 1)
+		}
+	}
+
+	domestic, err := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// All calls are ring-and-drop and unanswered: 0.8 * (0.7 + 0.3)
+	if domestic.Score < 0.799 || domestic.Score > 0.801 {
+		t.Errorf("Expected score 0.8 for ring-and-drop calls, got %f (%s)", domestic.Score, domestic.Reason)
+	}
+
+	premium, err := rule.Evaluate(ctx, "+88212345678", "", graphRepo)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(premium.Reason, "prefix +") {
+		t.Errorf("Expected reason to mention the premium prefix, got '%s'", premium.Reason)
+	}
+}
+
+func TestUnansweredCallRule_Evaluate_PrefixBoost(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	rule := newTestUnansweredCallRule()
+	ctx := context.Background()
+
+	// Half of the calls ring and drop
+	for _, caller := range []string{"14022000001", "0044123456789", "+919876500001"} {
+		for i := 0; i < 10; i++ {
+			addCallWithOutcome(t, graphRepo, caller, fmt.Sprintf("98765432%02d", i), i%2 == 1, 1)
+		}
+	}
+
+	domestic, _ := rule.Evaluate(ctx, "14022000001", "", graphRepo)
+	international, _ := rule.Evaluate(ctx, "0044123456789", "", graphRepo)
+	home, _ := rule.Evaluate(ctx, "+919876500001", "", graphRepo)
+
+	if international.Score <= domestic.Score {
+		t.Errorf("Expected international caller (%f) to score above domestic caller (%f)", international.Score, domestic.Score)
+	}
+
+	// A home number in international format is not international
+	if home.Score != domestic.Score {
+		t.Errorf("Expected +91 caller (%f) to score as domestic caller (%f)", home.Score, domestic.Score)
+	}
+}
@@ -2,7 +2,9 @@ package scoring
 
 import "credCode/models"
 
-// AverageScorer calculates spam score using average of all rule scores
+// AverageScorer calculates spam score using average of the applicable rule scores
+// Rules that report NotApplicable are left out instead of pulling the average towards 0;
+// when every rule applies this is the plain mean of all rule scores
 type AverageScorer struct{}
 
 // NewAverageScorer creates a new average-based scorer
@@ -10,19 +12,25 @@ func NewAverageScorer() Scorer {
 	return &AverageScorer{}
 }
 
-// CalculateScore calculates the average score from all rule scores
+// CalculateScore calculates the average score from the applicable rule scores
 // Returns: (averageScore, isSpam)
 func (s *AverageScorer) CalculateScore(scores []models.SpamScore, threshold float64) (float64, bool) {
-	if len(scores) == 0 {
-		return 0.0, false
-	}
-
 	var totalScore float64
+	var applicable int
 	for _, score := range scores {
+		if score.NotApplicable {
+			continue
+		}
+
 		totalScore += score.Score
+		applicable++
+	}
+
+	if applicable == 0 {
+		return 0.0, false
 	}
 
-	averageScore := totalScore / float64(len(scores))
+	averageScore := totalScore / float64(applicable)
 	isSpam := averageScore >= threshold
 
 	return averageScore, isSpam
@@ -0,0 +1,55 @@
+package scoring
+
+import (
+	"testing"
+
+	"credCode/models"
+)
+
+func TestAverageScorer_PlainMean(t *testing.T) {
+	scorer := NewAverageScorer()
+
+	scores := []models.SpamScore{
+		{RuleName: "spam_report_rule", Score: 0.8},
+		{RuleName: "contact_count_rule", Score: 0.4},
+		{RuleName: "second_level_contact_rule", Score: 0.0},
+	}
+
+	// (0.8 + 0.4 + 0.0) / 3
+	score, isSpam := scorer.CalculateScore(scores, 0.5)
+	if score < 0.399 || score > 0.401 || isSpam {
+		t.Errorf("Expected score 0.4 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
+
+func TestAverageScorer_NoScores(t *testing.T) {
+	scorer := NewAverageScorer()
+
+	score, isSpam := scorer.CalculateScore(nil, 0.5)
+	if score != 0.0 || isSpam {
+		t.Errorf("Expected score 0.0 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
+
+func TestAverageScorer_SkipsNotApplicable(t *testing.T) {
+	scorer := NewAverageScorer()
+
+	scores := []models.SpamScore{
+		{RuleName: "spam_report_rule", Score: 0.8},
+		{RuleName: "contact_count_rule", Score: 0.4},
+		{RuleName: "fan_out_rule", Score: 0.0, NotApplicable: true},
+		{RuleName: "second_level_contact_rule", Score: 0.0, NotApplicable: true},
+	}
+
+	// (0.8 + 0.4) / 2; the not applicable rules are left out
+	score, isSpam := scorer.CalculateScore(scores, 0.5)
+	if score < 0.599 || score > 0.601 || !isSpam {
+		t.Errorf("Expected score 0.6 (spam), got %f (spam: %v)", score, isSpam)
+	}
+
+	// With nothing applicable the number is not spam
+	score, isSpam = scorer.CalculateScore(scores[2:], 0.5)
+	if score != 0.0 || isSpam {
+		t.Errorf("Expected score 0.0 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
@@ -0,0 +1,110 @@
+package scoring
+
+import (
+	"errors"
+
+	"credCode/models"
+)
+
+var ErrInsufficientLabels = errors.New("labeled data needs both spam and non-spam examples")
+
+// LabeledScores are the rule scores of a number whose verdict is known
+type LabeledScores struct {
+	Scores []models.SpamScore
+	IsSpam bool
+}
+
+// classCounts accumulates soft firing counts of one rule within one class
+type classCounts struct {
+	fired float64 // Sum of scores
+	total float64 // Applicable examples
+}
+
+// FitBayes estimates the prior spam rate and the likelihood ratio of every rule from labeled examples
+// A score s counts as s firings and 1−s quiet outcomes, matching how BayesScorer reads scores.
+// smoothing is added to every count (Laplace smoothing), so rules seen in one class only still
+// get finite ratios; NotApplicable scores are not counted
+func FitBayes(examples []LabeledScores, smoothing float64) (float64, map[string]LikelihoodRatio, error) {
+	var spamCount, hamCount float64
+	spam := make(map[string]*classCounts)
+	ham := make(map[string]*classCounts)
+
+	for _, example := range examples {
+		counts := ham
+		if example.IsSpam {
+			counts = spam
+			spamCount++
+		} else {
+			hamCount++
+		}
+
+		for _, score := range example.Scores {
+			if score.NotApplicable {
+				continue
+			}
+
+			c, ok := counts[score.RuleName]
+			if !ok {
+				c = &classCounts{}
+				counts[score.RuleName] = c
+			}
+			c.fired += clampScore(score.Score)
+			c.total++
+		}
+	}
+
+	if spamCount == 0 || hamCount == 0 {
+		return 0, nil, ErrInsufficientLabels
+	}
+
+	prior := (spamCount + smoothing) / (spamCount + hamCount + 2*smoothing)
+
+	ruleNames := make(map[string]bool)
+	for ruleName := range spam {
+		ruleNames[ruleName] = true
+	}
+	for ruleName := range ham {
+		ruleNames[ruleName] = true
+	}
+
+	ratios := make(map[string]LikelihoodRatio, len(ruleNames))
+	for ruleName := range ruleNames {
+		s, h := spam[ruleName], ham[ruleName]
+		// Without smoothing, a rule seen in one class only carries no usable evidence
+		if s == nil || h == nil {
+			if smoothing <= 0 {
+				continue
+			}
+			if s == nil {
+				s = &classCounts{}
+			}
+			if h == nil {
+				h = &classCounts{}
+			}
+		}
+
+		firedSpam := (s.fired + smoothing) / (s.total + 2*smoothing)
+		firedHam := (h.fired + smoothing) / (h.total + 2*smoothing)
+		if firedSpam <= 0 || firedHam <= 0 || firedSpam >= 1 || firedHam >= 1 {
+			continue
+		}
+
+		ratios[ruleName] = LikelihoodRatio{
+			Fired: firedSpam / firedHam,
+			Quiet: (1.0 - firedSpam) / (1.0 - firedHam),
+		}
+	}
+
+	return prior, ratios, nil
+}
+
+// clampScore keeps a rule score within [0, 1]
+func clampScore(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
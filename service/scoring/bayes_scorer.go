@@ -0,0 +1,62 @@
+package scoring
+
+import (
+	"math"
+
+	"credCode/models"
+)
+
+// LikelihoodRatio is the evidence a rule carries: how much more likely its output is for a spam
+// number than for a legitimate one
+type LikelihoodRatio struct {
+	Fired float64 // P(rule fires | spam) / P(rule fires | not spam)
+	Quiet float64 // P(rule stays quiet | spam) / P(rule stays quiet | not spam)
+}
+
+// BayesScorer combines rule scores as independent evidence (naive Bayes) in log-odds space
+// Starting from the prior log-odds of spam, each applicable rule adds the log of its likelihood
+// ratio, and the sum is turned back into a probability. A rule score s is soft evidence: the rule
+// fired with probability s, so it adds s×ln(Fired) + (1−s)×ln(Quiet).
+// Rules without a likelihood ratio, or that report NotApplicable, add nothing
+type BayesScorer struct {
+	priorLogOdds float64                    // ln(prior / (1 − prior))
+	ratios       map[string]LikelihoodRatio // Rule name -> likelihood ratios
+}
+
+// NewBayesScorer creates a new naive Bayes scorer
+// priorSpamRate is the share of numbers that are spam before any rule is consulted (e.g., 0.1)
+func NewBayesScorer(priorSpamRate float64, ratios map[string]LikelihoodRatio) Scorer {
+	return &BayesScorer{
+		priorLogOdds: logOdds(priorSpamRate),
+		ratios:       ratios,
+	}
+}
+
+// CalculateScore calculates the probability that the number is spam given the rule scores
+// Returns: (spamProbability, isSpam)
+func (s *BayesScorer) CalculateScore(scores []models.SpamScore, threshold float64) (float64, bool) {
+	total := s.priorLogOdds
+
+	for _, score := range scores {
+		if score.NotApplicable {
+			continue
+		}
+
+		ratio, ok := s.ratios[score.RuleName]
+		if !ok || ratio.Fired <= 0 || ratio.Quiet <= 0 {
+			continue
+		}
+
+		fired := clampScore(score.Score)
+		total += fired*math.Log(ratio.Fired) + (1.0-fired)*math.Log(ratio.Quiet)
+	}
+
+	probability := 1.0 / (1.0 + math.Exp(-total))
+	return probability, probability >= threshold
+}
+
+// logOdds returns ln(p / (1 − p)), with p kept away from 0 and 1
+func logOdds(p float64) float64 {
+	p = math.Max(1e-6, math.Min(1.0-1e-6, p))
+	return math.Log(p / (1.0 - p))
+}
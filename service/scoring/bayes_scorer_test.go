@@ -0,0 +1,92 @@
+package scoring
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"credCode/models"
+)
+
+func TestBayesScorer_CombinesEvidenceInLogOdds(t *testing.T) {
+	scorer := NewBayesScorer(0.1, map[string]LikelihoodRatio{
+		"spam_report_rule":   {Fired: 9.0, Quiet: 0.5},
+		"contact_count_rule": {Fired: 3.0, Quiet: 0.5},
+	})
+
+	// Prior odds 1:9, times 9 for the report and 3 for the contacts: odds 3:1
+	score, isSpam := scorer.CalculateScore([]models.SpamScore{
+		{RuleName: "spam_report_rule", Score: 1.0},
+		{RuleName: "contact_count_rule", Score: 1.0},
+		{RuleName: "unknown_rule", Score: 1.0},
+		{RuleName: "trust_rank_rule", Score: 1.0, NotApplicable: true},
+	}, 0.5)
+	if math.Abs(score-0.75) > 1e-9 || !isSpam {
+		t.Errorf("Expected probability 0.75 (spam), got %f (spam: %v)", score, isSpam)
+	}
+
+	// Quiet rules are evidence against spam: odds 1:9 × 0.5 × 0.5 = 1:36
+	score, isSpam = scorer.CalculateScore([]models.SpamScore{
+		{RuleName: "spam_report_rule", Score: 0.0},
+		{RuleName: "contact_count_rule", Score: 0.0},
+	}, 0.5)
+	if math.Abs(score-1.0/37.0) > 1e-9 || isSpam {
+		t.Errorf("Expected probability 1/37 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
+
+func TestBayesScorer_NoEvidenceReturnsPrior(t *testing.T) {
+	scorer := NewBayesScorer(0.2, nil)
+
+	score, isSpam := scorer.CalculateScore(nil, 0.5)
+	if math.Abs(score-0.2) > 1e-9 || isSpam {
+		t.Errorf("Expected the prior 0.2 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
+
+func TestFitBayes(t *testing.T) {
+	examples := []LabeledScores{
+		{IsSpam: true, Scores: []models.SpamScore{{RuleName: "spam_report_rule", Score: 1.0}, {RuleName: "fan_out_rule", Score: 0.5}}},
+		{IsSpam: true, Scores: []models.SpamScore{{RuleName: "spam_report_rule", Score: 1.0}, {RuleName: "fan_out_rule", Score: 0.5}}},
+		{IsSpam: false, Scores: []models.SpamScore{{RuleName: "spam_report_rule", Score: 0.0}, {RuleName: "fan_out_rule", Score: 0.5}}},
+		{IsSpam: false, Scores: []models.SpamScore{{RuleName: "spam_report_rule", Score: 0.0}, {RuleName: "fan_out_rule", Score: 0.0, NotApplicable: true}}},
+		{IsSpam: false, Scores: []models.SpamScore{{RuleName: "spam_report_rule", Score: 0.0}}},
+	}
+
+	prior, ratios, err := FitBayes(examples, 1.0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// (2 + 1) / (5 + 2)
+	if math.Abs(prior-3.0/7.0) > 1e-9 {
+		t.Errorf("Expected prior 3/7, got %f", prior)
+	}
+
+	// Fires for (2+1)/(2+2) of spam and (0+1)/(3+2) of non-spam
+	report := ratios["spam_report_rule"]
+	if math.Abs(report.Fired-3.75) > 1e-9 || math.Abs(report.Quiet-0.3125) > 1e-9 {
+		t.Errorf("Expected spam report ratios {3.75 0.3125}, got %+v", report)
+	}
+
+	// Scores as often for spam as for non-spam: no evidence either way
+	fanOut := ratios["fan_out_rule"]
+	if math.Abs(fanOut.Fired-1.0) > 1e-9 || math.Abs(fanOut.Quiet-1.0) > 1e-9 {
+		t.Errorf("Expected fan out ratios {1 1}, got %+v", fanOut)
+	}
+
+	// The fitted scorer ranks a reported number above an unreported one
+	scorer := NewBayesScorer(prior, ratios)
+	reported, _ := scorer.CalculateScore(examples[0].Scores, 0.5)
+	unreported, _ := scorer.CalculateScore(examples[2].Scores, 0.5)
+	if reported <= unreported {
+		t.Errorf("Expected reported (%f) to score above unreported (%f)", reported, unreported)
+	}
+}
+
+func TestFitBayes_NeedsBothClasses(t *testing.T) {
+	_, _, err := FitBayes([]LabeledScores{{IsSpam: true}}, 1.0)
+	if !errors.Is(err, ErrInsufficientLabels) {
+		t.Errorf("Expected ErrInsufficientLabels, got %v", err)
+	}
+}
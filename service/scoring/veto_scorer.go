@@ -0,0 +1,46 @@
+package scoring
+
+import "credCode/models"
+
+// Veto lets a single rule force the verdict, regardless of the other rules
+// A spam veto (IsSpam true) fires when the rule scores at or above Threshold, e.g. the user's own
+// block; a not-spam veto fires when it scores at or below Threshold
+type Veto struct {
+	RuleName  string
+	Threshold float64
+	IsSpam    bool
+}
+
+// VetoScorer checks vetoes before handing the rule scores to another scorer
+type VetoScorer struct {
+	scorer Scorer
+	vetoes []Veto // Checked in order; the first to fire decides
+}
+
+// NewVetoScorer wraps a scorer with vetoes
+func NewVetoScorer(scorer Scorer, vetoes []Veto) Scorer {
+	return &VetoScorer{
+		scorer: scorer,
+		vetoes: vetoes,
+	}
+}
+
+// CalculateScore returns the vetoing rule's score with the forced verdict if a veto fires,
+// and the wrapped scorer's result otherwise
+func (s *VetoScorer) CalculateScore(scores []models.SpamScore, threshold float64) (float64, bool) {
+	for _, veto := range s.vetoes {
+		for _, score := range scores {
+			if score.RuleName != veto.RuleName || score.NotApplicable {
+				continue
+			}
+			if veto.IsSpam && score.Score >= veto.Threshold {
+				return score.Score, true
+			}
+			if !veto.IsSpam && score.Score <= veto.Threshold {
+				return score.Score, false
+			}
+		}
+	}
+
+	return s.scorer.CalculateScore(scores, threshold)
+}
@@ -0,0 +1,70 @@
+package scoring
+
+import (
+	"testing"
+
+	"credCode/models"
+)
+
+func TestVetoScorer_WeightedScorer(t *testing.T) {
+	scorer := NewVetoScorer(NewWeightedScorer(nil), []Veto{
+		{RuleName: "block_rule", Threshold: 1.0, IsSpam: true},
+		{RuleName: "allow_list_rule", Threshold: 0.0, IsSpam: false},
+	})
+
+	// The user's own block forces spam over otherwise clean scores
+	score, isSpam := scorer.CalculateScore([]models.SpamScore{
+		{RuleName: "block_rule", Score: 1.0},
+		{RuleName: "contact_count_rule", Score: 0.0},
+		{RuleName: "second_level_contact_rule", Score: 0.0},
+	}, 0.5)
+	if score != 1.0 || !isSpam {
+		t.Errorf("Expected veto score 1.0 (spam), got %f (spam: %v)", score, isSpam)
+	}
+
+	// Blocked by others (below the veto threshold) is averaged as usual
+	score, isSpam = scorer.CalculateScore([]models.SpamScore{
+		{RuleName: "block_rule", Score: 0.8},
+		{RuleName: "contact_count_rule", Score: 0.0},
+	}, 0.5)
+	if score < 0.399 || score > 0.401 || isSpam {
+		t.Errorf("Expected averaged score 0.4 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+
+	// A not-spam veto forces a clean verdict
+	score, isSpam = scorer.CalculateScore([]models.SpamScore{
+		{RuleName: "allow_list_rule", Score: 0.0},
+		{RuleName: "spam_report_rule", Score: 0.9},
+	}, 0.5)
+	if score != 0.0 || isSpam {
+		t.Errorf("Expected veto score 0.0 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
+
+func TestVetoScorer_BayesScorer(t *testing.T) {
+	bayes := NewBayesScorer(0.1, map[string]LikelihoodRatio{
+		"block_rule":         {Fired: 6.0, Quiet: 0.8},
+		"contact_count_rule": {Fired: 2.0, Quiet: 0.6},
+		"spam_report_rule":   {Fired: 8.0, Quiet: 0.6},
+	})
+	scorer := NewVetoScorer(bayes, []Veto{
+		{RuleName: "block_rule", Threshold: 1.0, IsSpam: true},
+	})
+
+	scores := []models.SpamScore{
+		{RuleName: "block_rule", Score: 1.0},
+		{RuleName: "contact_count_rule", Score: 0.0},
+		{RuleName: "spam_report_rule", Score: 0.0},
+	}
+
+	// On its own the evidence stays under 0.5 ...
+	if probability, _ := bayes.CalculateScore(scores, 0.5); probability >= 0.5 {
+		t.Fatalf("Expected the unvetoed probability below 0.5, got %f", probability)
+	}
+
+	// ... but the user's own block decides
+	score, isSpam := scorer.CalculateScore(scores, 0.5)
+	if score != 1.0 || !isSpam {
+		t.Errorf("Expected veto score 1.0 (spam), got %f (spam: %v)", score, isSpam)
+	}
+}
@@ -0,0 +1,49 @@
+package scoring
+
+import "credCode/models"
+
+// WeightedScorer calculates spam score as a weighted average of the applicable rule scores
+// Rules that report NotApplicable are left out instead of pulling the average towards 0
+type WeightedScorer struct {
+	weights       map[string]float64 // Rule name -> weight; 0 disables a rule
+	defaultWeight float64            // Weight of rules not listed in weights
+}
+
+// NewWeightedScorer creates a new weighted scorer
+// Rules missing from weights get weight 1.0
+func NewWeightedScorer(weights map[string]float64) Scorer {
+	return &WeightedScorer{
+		weights:       weights,
+		defaultWeight: 1.0,
+	}
+}
+
+// CalculateScore calculates the weighted average of the applicable rule scores
+// Returns: (weightedScore, isSpam)
+func (s *WeightedScorer) CalculateScore(scores []models.SpamScore, threshold float64) (float64, bool) {
+	var weightedTotal, totalWeight float64
+	for _, score := range scores {
+		if score.NotApplicable {
+			continue
+		}
+
+		weight := s.weightOf(score.RuleName)
+		weightedTotal += weight * score.Score
+		totalWeight += weight
+	}
+
+	if totalWeight == 0 {
+		return 0.0, false
+	}
+
+	weightedScore := weightedTotal / totalWeight
+	return weightedScore, weightedScore >= threshold
+}
+
+// weightOf returns the weight of a rule
+func (s *WeightedScorer) weightOf(ruleName string) float64 {
+	if weight, ok := s.weights[ruleName]; ok {
+		return weight
+	}
+	return s.defaultWeight
+}
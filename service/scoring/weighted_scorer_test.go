@@ -0,0 +1,38 @@
+package scoring
+
+import (
+	"testing"
+
+	"credCode/models"
+)
+
+func TestWeightedScorer_WeightsAndApplicability(t *testing.T) {
+	scorer := NewWeightedScorer(map[string]float64{
+		"spam_report_rule": 3.0,
+		"disabled_rule":    0.0,
+	})
+
+	scores := []models.SpamScore{
+		{RuleName: "spam_report_rule", Score: 0.8},
+		{RuleName: "contact_count_rule", Score: 0.4},
+		{RuleName: "disabled_rule", Score: 1.0},
+		{RuleName: "second_level_contact_rule", Score: 0.0, NotApplicable: true},
+	}
+
+	// (3 × 0.8 + 1 × 0.4) / 4; the disabled and not applicable rules are left out
+	score, isSpam := scorer.CalculateScore(scores, 0.5)
+	if score < 0.699 || score > 0.701 || !isSpam {
+		t.Errorf("Expected score 0.7 (spam), got %f (spam: %v)", score, isSpam)
+	}
+}
+
+func TestWeightedScorer_NothingApplicable(t *testing.T) {
+	scorer := NewWeightedScorer(nil)
+
+	score, isSpam := scorer.CalculateScore([]models.SpamScore{
+		{RuleName: "trust_rank_rule", Score: 0.0, NotApplicable: true},
+	}, 0.5)
+	if score != 0.0 || isSpam {
+		t.Errorf("Expected score 0.0 (not spam), got %f (spam: %v)", score, isSpam)
+	}
+}
@@ -18,12 +18,17 @@ type SpamDetectionService struct {
 	threshold float64 // Average score threshold to consider as spam (e.g., 0.5)
 }
 
-// NewSpamDetectionService creates a new spam detection service
+// NewSpamDetectionService creates a new spam detection service that averages rule scores
 func NewSpamDetectionService(graphRepo repository.GraphRepository, threshold float64) *SpamDetectionService {
+	return NewSpamDetectionServiceWithScorer(graphRepo, threshold, scoring.NewAverageScorer())
+}
+
+// NewSpamDetectionServiceWithScorer creates a new spam detection service that combines rule scores with scorer
+func NewSpamDetectionServiceWithScorer(graphRepo repository.GraphRepository, threshold float64, scorer scoring.Scorer) *SpamDetectionService {
 	service := &SpamDetectionService{
 		graphRepo: graphRepo,
 		registry:  NewSpamRuleRegistry(),
-		scorer:    scoring.NewAverageScorer(),
+		scorer:    scorer,
 		threshold: threshold,
 	}
 
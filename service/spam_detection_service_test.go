@@ -7,6 +7,7 @@ import (
 
 	"credCode/models"
 	"credCode/repository"
+	"credCode/service/scoring"
 )
 
 func TestNewSpamDetectionService(t *testing.T) {
@@ -108,3 +109,34 @@ func TestSpamDetectionService_GetRegisteredRules(t *testing.T) {
 	}
 }
 
+// fixedRule is a SpamRule returning a fixed score
+type fixedRule struct {
+	score models.SpamScore
+}
+
+func (r *fixedRule) Name() string {
+	return r.score.RuleName
+}
+
+func (r *fixedRule) Evaluate(ctx context.Context, phoneNumber string, userPhoneNumber string, graphRepo repository.GraphRepository) (*models.SpamScore, error) {
+	score := r.score
+	return &score, nil
+}
+
+func TestSpamDetectionService_DetectSpam_WithScorer(t *testing.T) {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	spamService := NewSpamDetectionServiceWithScorer(graphRepo, 0.5, scoring.NewWeightedScorer(nil))
+
+	spamService.RegisterRule(&fixedRule{score: models.SpamScore{RuleName: "reports", Score: 0.6}})
+	spamService.RegisterRule(&fixedRule{score: models.SpamScore{RuleName: "second_level", Score: 0.0, NotApplicable: true}})
+
+	result, err := spamService.DetectSpam("7379037972", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The not applicable rule is reported but does not dilute the score
+	if result.AverageScore != 0.6 || !result.IsSpam || len(result.RuleScores) != 2 {
+		t.Errorf("Expected score 0.6 (spam) over 2 rule scores, got %+v", result)
+	}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+var ErrTrustRankRunning = errors.New("trust rank is already running")
+
+// TrustRankResult summarises a trust rank run
+type TrustRankResult struct {
+	Nodes      int // Nodes scored
+	Seeds      int // Nodes the walk restarts from
+	Iterations int // Power iterations until convergence
+	Updated    int // Nodes whose stored score was written
+}
+
+// TrustRankJob computes a global trust score for every node with personalised PageRank (TrustRank)
+// A random walk follows has_contact edges (saving a number vouches for it) and restarts at
+// seed users: verified numbers plus users saved by many others. Numbers reachable from the
+// seeds through many short contact paths get high trust; numbers nobody trusted saved get none.
+//
+// Scores are stored on each node as the trust_rank property, squashed into [0, 1):
+// 0.5 is the score of an average node, 0 means the walk never reaches the node
+type TrustRankJob struct {
+	graphRepo     repository.GraphRepository
+	damping       float64  // Probability of following a contact edge instead of restarting (e.g., 0.85)
+	maxIterations int      // Upper bound on power iterations
+	tolerance     float64  // L1 change at which the iteration has converged
+	seedMinSaves  int      // Users saved by at least this many others seed the walk
+	seedNumbers   []string // Verified numbers that always seed the walk
+	running       sync.Mutex
+}
+
+// NewTrustRankJob creates a new trust rank job
+func NewTrustRankJob(graphRepo repository.GraphRepository, damping float64, maxIterations int, seedMinSaves int, seedNumbers []string) *TrustRankJob {
+	return &TrustRankJob{
+		graphRepo:     graphRepo,
+		damping:       damping,
+		maxIterations: maxIterations,
+		tolerance:     1e-9,
+		seedMinSaves:  seedMinSaves,
+		seedNumbers:   seedNumbers,
+	}
+}
+
+// Run computes trust scores from scratch and stores them on every node (offline job)
+func (j *TrustRankJob) Run(ctx context.Context) (*TrustRankResult, error) {
+	return j.run(ctx, false)
+}
+
+// RunIncremental recomputes trust scores after the graph changed
+// The walk is warm-started from the stored scores, so it converges in a few iterations,
+// and only nodes whose score moved are written back
+func (j *TrustRankJob) RunIncremental(ctx context.Context) (*TrustRankResult, error) {
+	return j.run(ctx, true)
+}
+
+// run performs the power iteration and stores the scores
+// Returns ErrTrustRankRunning if another run is in progress
+func (j *TrustRankJob) run(ctx context.Context, incremental bool) (*TrustRankResult, error) {
+	if !j.running.TryLock() {
+		return nil, ErrTrustRankRunning
+	}
+	defer j.running.Unlock()
+
+	// All nodes, from the prefix index
+	nodes := j.graphRepo.GetNumbersWithPrefix(ctx, "")
+	n := len(nodes)
+	result := &TrustRankResult{Nodes: n}
+	if n == 0 {
+		return result, nil
+	}
+
+	index := make(map[string]int, n)
+	for i, node := range nodes {
+		index[node] = i
+	}
+
+	// Contact adjacency: who each node has saved
+	out := make([][]int, n)
+	for i, node := range nodes {
+		for _, contact := range j.graphRepo.GetSavedContacts(ctx, node) {
+			if k, ok := index[contact]; ok {
+				out[i] = append(out[i], k)
+			}
+		}
+	}
+
+	seed := j.seedVector(ctx, nodes, index)
+	for _, weight := range seed {
+		if weight > 0 {
+			result.Seeds++
+		}
+	}
+
+	// Start from the seeds, or from the stored scores when running incrementally
+	rank := make([]float64, n)
+	copy(rank, seed)
+	var stored []float64
+	if incremental {
+		stored = j.storedScores(ctx, nodes)
+		if warm := warmStart(stored); warm != nil {
+			rank = warm
+		}
+	}
+
+	next := make([]float64, n)
+	for result.Iterations < j.maxIterations {
+		result.Iterations++
+
+		// Restart mass, plus the mass of nodes with no contacts (dangling nodes)
+		var dangling float64
+		for i := range rank {
+			if len(out[i]) == 0 {
+				dangling += rank[i]
+			}
+		}
+		for i := range next {
+			next[i] = ((1.0 - j.damping) + j.damping*dangling) * seed[i]
+		}
+
+		for i, contacts := range out {
+			if len(contacts) == 0 {
+				continue
+			}
+			share := j.damping * rank[i] / float64(len(contacts))
+			for _, k := range contacts {
+				next[k] += share
+			}
+		}
+
+		var delta float64
+		for i := range rank {
+			delta += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+
+		if delta < j.tolerance {
+			break
+		}
+	}
+
+	// Store the squashed scores in one batch
+	updates := make(map[string]map[string]interface{})
+	for i, node := range nodes {
+		score := squashTrustRank(rank[i], n)
+		if incremental && stored[i] >= 0 && math.Abs(stored[i]-score) < 1e-6 {
+			continue
+		}
+
+		updates[node] = map[string]interface{}{
+			repository.NodePropertyTrustRank: score,
+		}
+	}
+
+	if err := j.graphRepo.SetNodePropertiesBatch(ctx, updates); err != nil {
+		return nil, fmt.Errorf("failed to store trust rank: %w", err)
+	}
+	result.Updated = len(updates)
+
+	return result, nil
+}
+
+// seedVector returns the restart distribution: uniform over the seed users,
+// or over all nodes if there are no seeds
+func (j *TrustRankJob) seedVector(ctx context.Context, nodes []string, index map[string]int) []float64 {
+	isSeed := make([]bool, len(nodes))
+	count := 0
+
+	for _, number := range j.seedNumbers {
+		if i, ok := index[number]; ok && !isSeed[i] {
+			isSeed[i] = true
+			count++
+		}
+	}
+
+	for i, node := range nodes {
+		if !isSeed[i] && j.graphRepo.GetDegree(ctx, node, models.EdgeTypeContact, repository.DirectionIncoming) >= j.seedMinSaves {
+			isSeed[i] = true
+			count++
+		}
+	}
+
+	seed := make([]float64, len(nodes))
+	for i := range seed {
+		if count == 0 {
+			seed[i] = 1.0 / float64(len(nodes))
+		} else if isSeed[i] {
+			seed[i] = 1.0 / float64(count)
+		}
+	}
+
+	return seed
+}
+
+// storedScores returns the stored trust rank of each node, or -1 where none is stored
+func (j *TrustRankJob) storedScores(ctx context.Context, nodes []string) []float64 {
+	scores := make([]float64, len(nodes))
+	for i, node := range nodes {
+		scores[i] = -1
+		if value, ok := j.graphRepo.GetNodeProperty(ctx, node, repository.NodePropertyTrustRank); ok {
+			if score, ok := value.(float64); ok {
+				scores[i] = score
+			}
+		}
+	}
+	return scores
+}
+
+// warmStart turns stored scores back into a rank distribution, or returns nil if none are stored
+func warmStart(stored []float64) []float64 {
+	n := len(stored)
+	rank := make([]float64, n)
+
+	var total float64
+	for i, score := range stored {
+		if score > 0 && score < 1 {
+			rank[i] = unsquashTrustRank(score, n)
+			total += rank[i]
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	for i := range rank {
+		rank[i] /= total
+	}
+	return rank
+}
+
+// squashTrustRank maps a rank to [0, 1) relative to the average rank 1/n
+func squashTrustRank(rank float64, n int) float64 {
+	relative := rank * float64(n)
+	return relative / (1.0 + relative)
+}
+
+// unsquashTrustRank inverts squashTrustRank
+func unsquashTrustRank(score float64, n int) float64 {
+	return score / (1.0 - score) / float64(n)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"credCode/models"
+	"credCode/repository"
+)
+
+// trustRankOf returns the stored trust rank of a node
+func trustRankOf(t *testing.T, graphRepo repository.GraphRepository, phoneNumber string) float64 {
+	value, ok := graphRepo.GetNodeProperty(context.Background(), phoneNumber, repository.NodePropertyTrustRank)
+	if !ok {
+		t.Fatalf("Expected trust rank for %s", phoneNumber)
+	}
+	return value.(float64)
+}
+
+// buildTrustGraph builds a community of users who save each other and a popular hub,
+// plus a spam ring whose members only save each other
+func buildTrustGraph(t *testing.T) repository.GraphRepository {
+	graphRepo := repository.NewInMemoryGraphRepository()
+	ctx := context.Background()
+
+	save := func(from, to string) {
+		if _, err := graphRepo.AddEdgeWithMetadata(ctx, from, to, &models.ContactMetadata{Name: "Contact", AddedAt: time.Now()}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// Community: everyone saves the hub, the hub saves a friend and the friend saves a plumber
+	for i := 0; i < 6; i++ {
+		member := fmt.Sprintf("737903797%d", i)
+		save(member, "9876543210")
+		save("9876543210", member)
+	}
+	save("9876543210", "9876543211")
+	save("9876543211", "9876543212")
+
+	// Spam ring
+	save("14022000001", "14022000002")
+	save("14022000002", "14022000001")
+
+	return graphRepo
+}
+
+func TestTrustRankJob_Run(t *testing.T) {
+	graphRepo := buildTrustGraph(t)
+	ctx := context.Background()
+
+	job := NewTrustRankJob(graphRepo, 0.85, 100, 5, nil)
+	result, err := job.Run(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Nodes != 11 || result.Seeds != 1 || result.Updated != 11 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	hub := trustRankOf(t, graphRepo, "9876543210")
+	friend := trustRankOf(t, graphRepo, "9876543211")
+	plumber := trustRankOf(t, graphRepo, "9876543212")
+	spammer := trustRankOf(t, graphRepo, "14022000001")
+
+	// Trust decays with distance from the seed; the spam ring is unreachable
+	if !(hub > friend && friend > plumber && plumber > 0) {
+		t.Errorf("Expected hub > friend > plumber > 0, got %f, %f, %f", hub, friend, plumber)
+	}
+	if spammer != 0 {
+		t.Errorf("Expected zero trust for the spam ring, got %f", spammer)
+	}
+}
+
+func TestTrustRankJob_VerifiedSeeds(t *testing.T) {
+	graphRepo := buildTrustGraph(t)
+	ctx := context.Background()
+
+	// A verified seed vouches for the spam ring's first member
+	graphRepo.AddEdgeWithMetadata(ctx, "18001000001", "14022000001", &models.ContactMetadata{Name: "Client", AddedAt: time.Now()})
+
+	job := NewTrustRankJob(graphRepo, 0.85, 100, 5, []string{"18001000001"})
+	if _, err := job.Run(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if trust := trustRankOf(t, graphRepo, "14022000001"); trust == 0 {
+		t.Error("Expected trust to reach a number saved by a verified seed")
+	}
+}
+
+func TestTrustRankJob_RunIncremental(t *testing.T) {
+	graphRepo := buildTrustGraph(t)
+	ctx := context.Background()
+
+	job := NewTrustRankJob(graphRepo, 0.85, 100, 5, nil)
+	full, err := job.Run(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Nothing changed: converges immediately and writes nothing
+	result, err := job.RunIncremental(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Updated != 0 || result.Iterations >= full.Iterations {
+		t.Errorf("Expected a warm start with no updates, got %+v (full run took %d iterations)", result, full.Iterations)
+	}
+
+	// The hub saves a new number; only changed scores are written
+	graphRepo.AddEdgeWithMetadata(ctx, "9876543210", "9876543213", &models.ContactMetadata{Name: "Doctor", AddedAt: time.Now()})
+
+	result, err = job.RunIncremental(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Updated == 0 || result.Updated > result.Nodes {
+		t.Errorf("Expected some nodes updated, got %+v", result)
+	}
+	if trust := trustRankOf(t, graphRepo, "9876543213"); trust == 0 {
+		t.Error("Expected the new contact to get trust")
+	}
+}